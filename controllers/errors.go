@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"product-api/crud"
+)
+
+// errorStatus traduz os erros classificados pelo crud no status HTTP
+// correspondente; os demais recebem fallback.
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, crud.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, crud.ErrStaleObject),
+		errors.Is(err, crud.ErrDuplicate),
+		errors.Is(err, crud.ErrForeignKey):
+		return http.StatusConflict
+	case errors.Is(err, crud.ErrValueTooLarge),
+		errors.Is(err, crud.ErrKeyAboveSeq):
+		return http.StatusBadRequest
+	case errors.Is(err, crud.ErrTimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, crud.ErrConnection):
+		return http.StatusServiceUnavailable
+	}
+	return fallback
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidIfMatch = errors.New("If-Match inválido")
+
+// setETag expõe a versão do registro para controle de concorrência otimista.
+func setETag(ctx *gin.Context, version int64) {
+	ctx.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion lê a versão esperada do header If-Match. Ausente ou "*"
+// significa que o cliente não exige versão (retorna zero). If-Match usa
+// comparação forte (RFC 9110), então ETags fracas (W/"n") são recusadas.
+func ifMatchVersion(ctx *gin.Context) (int64, error) {
+	value := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	if strings.HasPrefix(value, "W/") {
+		return 0, errInvalidIfMatch
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errInvalidIfMatch
+	}
+
+	return version, nil
+}
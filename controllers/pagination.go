@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"product-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setPageLinks escreve o header Link (RFC 8288) preservando os demais query params.
+func setPageLinks(ctx *gin.Context, page models.Page, total int64) {
+	last := int((total + int64(page.Size) - 1) / int64(page.Size))
+	if last < 1 {
+		last = 1
+	}
+
+	link := func(number int, rel string) string {
+		u := *ctx.Request.URL
+		q := u.Query()
+		q.Set("page", strconv.Itoa(number))
+		q.Set("size", strconv.Itoa(page.Size))
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if page.Number > 1 {
+		links = append(links, link(min(page.Number-1, last), "prev"))
+	}
+	if page.Number < last {
+		links = append(links, link(page.Number+1, "next"))
+	}
+	links = append(links, link(last, "last"))
+
+	ctx.Header("Link", strings.Join(links, ", "))
+}
+
+func setCursorLinks(ctx *gin.Context, page models.CursorPage) {
+	link := func(token, rel string) string {
+		u := *ctx.Request.URL
+		q := u.Query()
+		q.Set("cursor", token)
+		q.Set("limit", strconv.Itoa(page.Limit))
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+	}
+
+	var links []string
+	if page.Prev != "" {
+		links = append(links, link(page.Prev, "prev"))
+	}
+	if page.Next != "" {
+		links = append(links, link(page.Next, "next"))
+	}
+
+	if len(links) > 0 {
+		ctx.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -1,17 +1,23 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"product-api/crud"
 	"product-api/facade"
+	"product-api/jsonpatch"
 	"product-api/logger"
 	"product-api/mappers"
+	"product-api/models"
 
 	"product-api/dto/request"
 	"product-api/dto/response"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -31,7 +37,10 @@ func NewProductController(f *facade.ProductFacade) *ProductController {
 // @Produce json
 // @Param product body request.ProductRequestDTO true "Produto a ser criado"
 // @Success 201 {object} response.ProductResponseDTO
-// @Failure 400 {object} map[string]string
+// @Header 201 {string} ETag "Versão do produto"
+// @Failure 400 {object} response.ErrorResponseDTO
+// @Failure 409 {object} response.ErrorResponseDTO
+// @Failure 500 {object} response.ErrorResponseDTO
 // @Router /products [post]
 func (c *ProductController) Create(ctx *gin.Context) {
 	var req request.ProductRequestDTO
@@ -53,10 +62,11 @@ func (c *ProductController) Create(ctx *gin.Context) {
 	product := mappers.ToProductModel(req)
 
 	// Criação do produto
-	created, err := c.facade.Create(product)
+	created, err := c.facade.Create(ctx.Request.Context(), product)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponseDTO{
-			Status: http.StatusInternalServerError,
+		status := errorStatus(err, http.StatusInternalServerError)
+		ctx.JSON(status, response.ErrorResponseDTO{
+			Status: status,
 			Info:   "Erro ao criar produto",
 		})
 		logger.Logger.WithFields(log.Fields{
@@ -67,23 +77,138 @@ func (c *ProductController) Create(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, created.Version)
+
 	resp := mappers.ToProductResponse(created)
 	ctx.JSON(http.StatusCreated, resp)
 }
 
+// CreateMany godoc
+// @Summary Criar produtos em lote
+// @Description Cria vários produtos em um único comando no banco. Itens inválidos são
+// @Description reportados em errors pelo índice no lote, sem impedir a criação dos demais.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param products body []request.ProductRequestDTO true "Produtos a serem criados (máximo 5000)"
+// @Success 201 {object} response.BulkResponseDTO[response.ProductResponseDTO]
+// @Success 207 {object} response.BulkResponseDTO[response.ProductResponseDTO]
+// @Failure 400 {object} response.BulkResponseDTO[response.ProductResponseDTO]
+// @Failure 500 {object} response.ErrorResponseDTO
+// @Router /products/bulk [post]
+func (c *ProductController) CreateMany(ctx *gin.Context) {
+	var req []request.ProductRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Erro ao criar produtos",
+		})
+		logger.Logger.WithFields(log.Fields{
+			"method": "CreateMany",
+			"error":  err,
+		}).Error("Falha ao bindar JSON")
+		return
+	}
+	if len(req) > facade.MaxBulkSize {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Lote excede o tamanho máximo",
+		})
+		return
+	}
+
+	created, failures, err := c.facade.CreateMany(ctx.Request.Context(), mappers.ToProductModelList(req))
+	if err != nil {
+		status := errorStatus(err, http.StatusInternalServerError)
+		ctx.JSON(status, response.ErrorResponseDTO{
+			Status: status,
+			Info:   "Erro ao criar produtos",
+		})
+		logger.Logger.WithFields(log.Fields{
+			"method": "CreateMany",
+			"count":  len(req),
+			"error":  err,
+		}).Error("Erro ao salvar produtos no banco")
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case len(failures) > 0 && len(created) > 0:
+		status = http.StatusMultiStatus
+	case len(failures) > 0:
+		status = http.StatusBadRequest
+	}
+
+	resp := mappers.ToProductBulkResponse(created, failures)
+	ctx.JSON(status, resp)
+}
+
 // List godoc
 // @Summary Listar produtos
-// @Description Retorna lista de produtos
+// @Description Retorna lista de produtos paginada por offset (page/size).
+// @Description Quando cursor ou limit são informados, usa paginação por keyset e responde com response.CursorPageResponseDTO.
 // @Tags Products
 // @Produce json
-// @Success 200 {array} response.ProductResponseDTO
+// @Param name query string false "Filtra pelo nome (contém)"
+// @Param min_price query number false "Preço mínimo"
+// @Param max_price query number false "Preço máximo"
+// @Param page query int false "Página (a partir de 1)" default(1)
+// @Param size query int false "Itens por página (máximo 100)" default(20)
+// @Param include_deleted query bool false "Inclui produtos excluídos"
+// @Param sort query string false "Ordenação, ex.: -price,name (campos: id, name, price)"
+// @Param cursor query string false "Token de cursor retornado em next/prev"
+// @Param limit query int false "Itens por página no modo cursor (máximo 100)"
+// @Success 200 {object} response.PageResponseDTO[response.ProductResponseDTO]
+// @Header 200 {string} Link "Links para first, prev, next e last"
+// @Failure 400 {object} response.ErrorResponseDTO
 // @Failure 500 {object} map[string]string
 // @Router /products [get]
 func (c *ProductController) List(ctx *gin.Context) {
-	products, err := c.facade.List()
+	var req request.ProductFilterDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Filtro inválido",
+		})
+		return
+	}
+
+	var cursorReq request.CursorRequestDTO
+	if err := ctx.ShouldBindQuery(&cursorReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Paginação inválida",
+		})
+		return
+	}
+	if cursorReq.Cursor != "" || cursorReq.Limit != 0 {
+		c.listByCursor(ctx, mappers.ToProductFilter(req), cursorReq)
+		return
+	}
+
+	var pageReq request.PageRequestDTO
+	if err := ctx.ShouldBindQuery(&pageReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Paginação inválida",
+		})
+		return
+	}
+
+	products, page, total, err := c.facade.List(ctx.Request.Context(), mappers.ToProductFilter(req), mappers.ToPage(pageReq))
+	if errors.Is(err, crud.ErrInvalidSort) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Ordenação inválida",
+		})
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.ErrorResponseDTO{
-			Status: http.StatusInternalServerError,
+		status := errorStatus(err, http.StatusInternalServerError)
+		ctx.JSON(status, response.ErrorResponseDTO{
+			Status: status,
 			Info:   "Erro ao listar produto",
 		})
 		logger.Logger.WithFields(log.Fields{
@@ -93,7 +218,84 @@ func (c *ProductController) List(ctx *gin.Context) {
 		return
 	}
 
-	resp := mappers.ToProductResponseList(products)
+	setPageLinks(ctx, page, total)
+
+	resp := mappers.ToProductPageResponse(products, page, total)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Stats godoc
+// @Summary Estatísticas de produtos
+// @Description Retorna a quantidade e o preço mínimo, máximo e médio dos produtos do filtro.
+// @Tags Products
+// @Produce json
+// @Param name query string false "Filtra pelo nome (contém)"
+// @Param min_price query number false "Preço mínimo"
+// @Param max_price query number false "Preço máximo"
+// @Param include_deleted query bool false "Inclui produtos excluídos"
+// @Success 200 {object} response.ProductStatsResponseDTO
+// @Failure 400 {object} response.ErrorResponseDTO
+// @Failure 500 {object} response.ErrorResponseDTO
+// @Router /products/stats [get]
+func (c *ProductController) Stats(ctx *gin.Context) {
+	var req request.ProductFilterDTO
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Filtro inválido",
+		})
+		return
+	}
+
+	stats, err := c.facade.Stats(ctx.Request.Context(), mappers.ToProductFilter(req))
+	if err != nil {
+		status := errorStatus(err, http.StatusInternalServerError)
+		ctx.JSON(status, response.ErrorResponseDTO{
+			Status: status,
+			Info:   "Erro ao calcular estatísticas",
+		})
+		logger.Logger.WithFields(log.Fields{
+			"method": "Stats",
+			"error":  err,
+		}).Error("Erro ao agregar produtos no banco")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mappers.ToProductStatsResponse(stats))
+}
+
+func (c *ProductController) listByCursor(ctx *gin.Context, filter models.ProductFilter, req request.CursorRequestDTO) {
+	products, page, err := c.facade.ListByCursor(ctx.Request.Context(), filter, req.Cursor, req.Limit)
+	if errors.Is(err, crud.ErrInvalidCursor) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Cursor inválido",
+		})
+		return
+	}
+	if errors.Is(err, crud.ErrInvalidSort) {
+		ctx.JSON(http.StatusBadRequest, response.ErrorResponseDTO{
+			Status: http.StatusBadRequest,
+			Info:   "Ordenação inválida",
+		})
+		return
+	}
+	if err != nil {
+		status := errorStatus(err, http.StatusInternalServerError)
+		ctx.JSON(status, response.ErrorResponseDTO{
+			Status: status,
+			Info:   "Erro ao listar produto",
+		})
+		logger.Logger.WithFields(log.Fields{
+			"method": "List",
+			"error":  err,
+		}).Error("Erro ao listar produtos do banco")
+		return
+	}
+
+	setCursorLinks(ctx, page)
+
+	resp := mappers.ToProductCursorPageResponse(products, page)
 	ctx.JSON(http.StatusOK, resp)
 }
 
@@ -103,7 +305,9 @@ func (c *ProductController) List(ctx *gin.Context) {
 // @Tags Products
 // @Produce json
 // @Param id path int true "ID do produto"
+// @Param include_deleted query bool false "Inclui produto excluído"
 // @Success 200 {object} response.ProductResponseDTO
+// @Header 200 {string} ETag "Versão do produto"
 // @Failure 500 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Router /products/{id} [get]
@@ -114,27 +318,43 @@ func (c *ProductController) FindByID(ctx *gin.Context) {
 		return
 	}
 
-	p, err := c.facade.FindByID(id)
+	includeDeleted, err := strconv.ParseBool(ctx.DefaultQuery("include_deleted", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "include_deleted inválido"})
+		return
+	}
+
+	p, err := c.facade.FindByID(ctx.Request.Context(), id, includeDeleted)
+	if errors.Is(err, crud.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(ctx, p.Version)
+
 	resp := mappers.ToProductResponse(p)
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // Update godoc
 // @Summary Atualizar produto
-// @Description Atualiza os dados de um produto
+// @Description Atualiza os dados de um produto, criando-o com o ID informado quando não existe. IDs ainda não emitidos pela sequence são recusados com 400.
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param id path int true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida; exige que o produto exista e não tenha mudado"
 // @Param product body request.ProductRequestDTO true "Dados do produto"
 // @Success 200 {object} response.ProductResponseDTO
+// @Success 201 {object} response.ProductResponseDTO
+// @Header 200,201 {string} ETag "Versão do produto"
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /products/{id} [put]
 func (c *ProductController) Update(ctx *gin.Context) {
 	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
@@ -149,25 +369,126 @@ func (c *ProductController) Update(ctx *gin.Context) {
 		return
 	}
 
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	product := mappers.ToProductModel(req)
 
-	updated, err := c.facade.Update(id, product)
+	updated, created, err := c.facade.Update(ctx.Request.Context(), id, version, product)
+	if errors.Is(err, crud.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+		return
+	}
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(ctx, updated.Version)
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
 	resp := mappers.ToProductResponse(updated)
+	ctx.JSON(status, resp)
+}
+
+// Patch godoc
+// @Summary Atualizar produto parcialmente
+// @Description Aplica um JSON Merge Patch (RFC 7396) ao produto; apenas os campos enviados são alterados
+// @Tags Products
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida"
+// @Param patch body request.ProductPatchDTO true "Campos a alterar"
+// @Success 200 {object} response.ProductResponseDTO
+// @Header 200 {string} ETag "Versão do produto"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Router /products/{id} [patch]
+func (c *ProductController) Patch(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id inválido"})
+		return
+	}
+
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "use application/merge-patch+json"})
+		return
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	patch, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	apply := func(current models.Product) (models.Product, error) {
+		doc, err := json.Marshal(mappers.ToProductRequest(current))
+		if err != nil {
+			return current, err
+		}
+
+		merged, err := jsonpatch.MergePatch(doc, patch)
+		if err != nil {
+			return current, err
+		}
+
+		var req request.ProductRequestDTO
+		if err := json.Unmarshal(merged, &req); err != nil {
+			return current, errors.Join(jsonpatch.ErrInvalidPatch, err)
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			return current, errors.Join(jsonpatch.ErrInvalidPatch, err)
+		}
+
+		return mappers.ApplyProductRequest(current, req), nil
+	}
+
+	patched, err := c.facade.Patch(ctx.Request.Context(), id, version, apply)
+	if errors.Is(err, crud.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(ctx, patched.Version)
+
+	resp := mappers.ToProductResponse(patched)
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // Delete godoc
 // @Summary Deletar produto
-// @Description Remove um produto pelo ID
+// @Description Exclui logicamente um produto pelo ID; ele pode ser restaurado em /products/{id}/restore
 // @Tags Products
 // @Param id path int true "ID do produto"
+// @Param If-Match header string false "ETag da versão lida"
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /products/{id} [delete]
 func (c *ProductController) Delete(ctx *gin.Context) {
@@ -177,10 +498,56 @@ func (c *ProductController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.facade.Delete(id); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.facade.Delete(ctx.Request.Context(), id, version)
+	if errors.Is(err, crud.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// Restore godoc
+// @Summary Restaurar produto
+// @Description Desfaz a exclusão lógica de um produto
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID do produto"
+// @Success 200 {object} response.ProductResponseDTO
+// @Header 200 {string} ETag "Versão do produto"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /products/{id}/restore [post]
+func (c *ProductController) Restore(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "id inválido"})
+		return
+	}
+
+	restored, err := c.facade.Restore(ctx.Request.Context(), id)
+	if errors.Is(err, crud.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(ctx, restored.Version)
+
+	resp := mappers.ToProductResponse(restored)
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,246 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Exists indica se alguma linha de table satisfaz cond, sem carregar linhas.
+// model é usado apenas para validar as colunas da condição.
+func (c *Crud) Exists(ctx context.Context, table string, model any, cond Condition, opts ...QueryOption) (bool, error) {
+	table, err := c.table(table)
+	if err != nil {
+		return false, err
+	}
+
+	meta, err := metaOf(reflect.TypeOf(model))
+	if err != nil {
+		return false, err
+	}
+
+	whereSQL, args, err := where(meta, meta.scope(cond, newQueryOptions(opts)), nil)
+	if err != nil {
+		return false, err
+	}
+
+	var exists int
+	query := fmt.Sprintf("SELECT CASE WHEN EXISTS (SELECT 1 FROM %s%s) THEN 1 ELSE 0 END FROM dual", table, whereSQL)
+	err = c.queryRow(ctx, query, args, &exists)
+	return exists == 1, err
+}
+
+// Sum, Min, Max e Avg calculam a agregação de column sobre as linhas de table
+// que satisfazem cond. O resultado é NULL (Valid false) quando nenhuma linha
+// participa.
+func Sum[V any](ctx context.Context, c *Crud, table string, model any, column string, cond Condition, opts ...QueryOption) (Null[V], error) {
+	return aggregateOne[V](ctx, c, table, model, "SUM", column, cond, opts)
+}
+
+func Min[V any](ctx context.Context, c *Crud, table string, model any, column string, cond Condition, opts ...QueryOption) (Null[V], error) {
+	return aggregateOne[V](ctx, c, table, model, "MIN", column, cond, opts)
+}
+
+func Max[V any](ctx context.Context, c *Crud, table string, model any, column string, cond Condition, opts ...QueryOption) (Null[V], error) {
+	return aggregateOne[V](ctx, c, table, model, "MAX", column, cond, opts)
+}
+
+func Avg[V any](ctx context.Context, c *Crud, table string, model any, column string, cond Condition, opts ...QueryOption) (Null[V], error) {
+	return aggregateOne[V](ctx, c, table, model, "AVG", column, cond, opts)
+}
+
+func aggregateOne[V any](ctx context.Context, c *Crud, table string, model any, fn, column string, cond Condition, opts []QueryOption) (Null[V], error) {
+	var result struct {
+		Value Null[V]
+	}
+	spec := &aggregateSpec{items: []aggregateItem{{index: []int{0}, fn: fn, column: column}}}
+
+	err := c.aggregate(ctx, table, model, reflect.ValueOf(&result).Elem(), spec, cond, opts)
+	return result.Value, err
+}
+
+// GroupBy agrupa as linhas de table que satisfazem cond e devolve uma linha
+// de R por grupo. Os campos de R declaram, na tag db, a coluna do model e a
+// função: `db:"NAME,group"` agrupa por NAME, `db:"PRICE,avg"` calcula a média
+// (também sum, min, max e count; `db:",count"` conta as linhas).
+func GroupBy[R any](ctx context.Context, c *Crud, table string, model any, cond Condition, opts ...QueryOption) ([]R, error) {
+	var rows []R
+	err := c.Aggregate(ctx, table, model, &rows, cond, opts...)
+	return rows, err
+}
+
+// Aggregate executa as agregações declaradas nas tags db de dest: ponteiro
+// para struct (uma linha, sem campos `group`) ou ponteiro para slice de struct
+// (uma linha por grupo, em ordem das colunas agrupadas). Ver GroupBy.
+func (c *Crud) Aggregate(ctx context.Context, table string, model any, dest any, cond Condition, opts ...QueryOption) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || (v.Elem().Kind() != reflect.Struct && v.Elem().Kind() != reflect.Slice) {
+		return fmt.Errorf("dest deve ser ponteiro para struct ou slice de struct")
+	}
+
+	resultType := v.Elem().Type()
+	if resultType.Kind() == reflect.Slice {
+		resultType = resultType.Elem()
+	}
+
+	spec, err := aggregateSpecOf(resultType)
+	if err != nil {
+		return err
+	}
+
+	return c.aggregate(ctx, table, model, v.Elem(), spec, cond, opts)
+}
+
+// aggregateItem é um campo do struct de resultado: a função (ou GROUP) e a
+// coluna do model sobre a qual ela é aplicada.
+type aggregateItem struct {
+	index  []int
+	fn     string
+	column string
+}
+
+type aggregateSpec struct {
+	items  []aggregateItem
+	groups bool
+}
+
+var (
+	aggregateFuncs = map[string]string{
+		"count": "COUNT",
+		"sum":   "SUM",
+		"min":   "MIN",
+		"max":   "MAX",
+		"avg":   "AVG",
+		"group": "",
+	}
+
+	aggregateCache sync.Map // reflect.Type -> *aggregateSpec
+)
+
+func aggregateSpecOf(t reflect.Type) (*aggregateSpec, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("resultado de agregação deve ser struct, recebido %s", t)
+	}
+	if cached, ok := aggregateCache.Load(t); ok {
+		return cached.(*aggregateSpec), nil
+	}
+
+	spec := &aggregateSpec{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		column, options := parseTag(tag)
+
+		item := aggregateItem{index: sf.Index, column: column, fn: "?"}
+		for name := range options {
+			fn, ok := aggregateFuncs[name]
+			if !ok || item.fn != "?" {
+				return nil, fmt.Errorf("%s.%s: informe uma única função entre group, count, sum, min, max e avg", t, sf.Name)
+			}
+			item.fn = fn
+		}
+		if item.fn == "?" {
+			return nil, fmt.Errorf("%s.%s: função de agregação ausente", t, sf.Name)
+		}
+		if item.column == "" && item.fn != "COUNT" {
+			return nil, fmt.Errorf("%s.%s: coluna ausente", t, sf.Name)
+		}
+
+		spec.groups = spec.groups || item.fn == ""
+		spec.items = append(spec.items, item)
+	}
+
+	if len(spec.items) == 0 {
+		return nil, fmt.Errorf("%s não declara agregações em tags db", t)
+	}
+
+	actual, _ := aggregateCache.LoadOrStore(t, spec)
+	return actual.(*aggregateSpec), nil
+}
+
+func (c *Crud) aggregate(ctx context.Context, table string, model any, dest reflect.Value, spec *aggregateSpec, cond Condition, opts []QueryOption) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	meta, err := metaOf(reflect.TypeOf(model))
+	if err != nil {
+		return err
+	}
+
+	if spec.groups && dest.Kind() != reflect.Slice {
+		return fmt.Errorf("agregação com group exige dest ponteiro para slice")
+	}
+
+	exprs := make([]string, 0, len(spec.items))
+	var groups []string
+	for _, item := range spec.items {
+		if item.column == "" {
+			exprs = append(exprs, "COUNT(*)")
+			continue
+		}
+
+		f := meta.fieldByColumn(item.column)
+		if f == nil {
+			return fmt.Errorf("%w: %q não mapeada em %s", ErrInvalidColumn, item.column, meta.Type)
+		}
+
+		if item.fn == "" {
+			exprs = append(exprs, f.Column)
+			groups = append(groups, f.Column)
+			continue
+		}
+		exprs = append(exprs, fmt.Sprintf("%s(%s)", item.fn, f.Column))
+	}
+
+	whereSQL, args, err := where(meta, meta.scope(cond, newQueryOptions(opts)), nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s%s", strings.Join(exprs, ", "), table, whereSQL)
+	if len(groups) > 0 {
+		query += fmt.Sprintf(" GROUP BY %s ORDER BY %s", strings.Join(groups, ", "), strings.Join(groups, ", "))
+	}
+
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	targets := make([]any, len(spec.items))
+	scan := func(row reflect.Value) error {
+		for i, item := range spec.items {
+			targets[i] = row.FieldByIndex(item.index).Addr().Interface()
+		}
+		return translate(rows.Scan(targets...))
+	}
+
+	if dest.Kind() == reflect.Struct {
+		// sem GROUP BY a consulta sempre devolve exatamente uma linha
+		if rows.Next() {
+			if err := scan(dest); err != nil {
+				return err
+			}
+		}
+		return translate(rows.Err())
+	}
+
+	for rows.Next() {
+		row := reflect.New(dest.Type().Elem()).Elem()
+		if err := scan(row); err != nil {
+			return err
+		}
+		dest.Set(reflect.Append(dest, row))
+	}
+
+	return translate(rows.Err())
+}
@@ -0,0 +1,120 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	go_ora "github.com/sijms/go-ora/v2"
+)
+
+type principalKey struct{}
+
+// WithPrincipal associa ao contexto o usuário autenticado, gravado pelo crud
+// nas colunas `createdby` e `updatedby`.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom devolve o usuário associado ao contexto, ou "" se não houver.
+func PrincipalFrom(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
+
+// principalExpr usa o usuário do contexto e, na falta dele, o usuário da
+// sessão Oracle (no Oracle, string vazia é NULL).
+func principalExpr(bindName string) string {
+	return fmt.Sprintf("NVL(%s, USER)", bindName)
+}
+
+// bind descreve a origem de um parâmetro posicional: um campo do model ou,
+// quando principal é true, o usuário do contexto.
+type bind struct {
+	field     *field
+	principal bool
+}
+
+func (f *field) timestampAudit() bool {
+	return f.has("autocreate") || f.has("autoupdate")
+}
+
+func (f *field) principalAudit() bool {
+	return f.has("createdby") || f.has("updatedby")
+}
+
+func (f *field) audit() bool {
+	return f.timestampAudit() || f.principalAudit()
+}
+
+// touchedOnUpdate indica colunas reescritas pelo crud em qualquer UPDATE.
+func (f *field) touchedOnUpdate() bool {
+	return f.has("autoupdate") || f.has("updatedby")
+}
+
+// touchSets gera os SETs mantidos pelo crud em todo UPDATE (versão e
+// auditoria). next devolve o próximo placeholder posicional.
+func (m *modelMeta) touchSets(next func() string) ([]string, []bind) {
+	var sets []string
+	var binds []bind
+
+	if m.Version != nil {
+		sets = append(sets, fmt.Sprintf("%s = %s + 1", m.Version.Column, m.Version.Column))
+	}
+	for _, f := range m.Fields {
+		switch {
+		case f.has("autoupdate"):
+			sets = append(sets, f.Column+" = SYSTIMESTAMP")
+		case f.has("updatedby"):
+			sets = append(sets, fmt.Sprintf("%s = %s", f.Column, principalExpr(next())))
+			binds = append(binds, bind{principal: true})
+		}
+	}
+
+	return sets, binds
+}
+
+// bindArgs monta os argumentos posicionais a partir do model e do contexto.
+func bindArgs(ctx context.Context, meta *modelMeta, v reflect.Value, binds []bind) []any {
+	args := make([]any, 0, len(binds))
+	for _, b := range binds {
+		if b.principal {
+			args = append(args, PrincipalFrom(ctx))
+			continue
+		}
+		args = append(args, b.field.bindValue(v))
+	}
+	return args
+}
+
+// outArgs cria os parâmetros OUT de um RETURNING, apontando para os campos do
+// model quando ele é endereçável.
+func outArgs(v reflect.Value, fields []*field) []any {
+	args := make([]any, 0, len(fields))
+	for _, f := range fields {
+		var dest any
+		if v.CanAddr() {
+			dest = v.FieldByIndex(f.Index).Addr().Interface()
+		} else {
+			dest = reflect.New(v.Type().FieldByIndex(f.Index).Type).Interface()
+		}
+		args = append(args, outParam(dest))
+	}
+	return args
+}
+
+// outParam informa tamanho para OUT de texto; sem isso o go-ora limita o
+// buffer ao tamanho do valor atual do campo.
+func outParam(dest any) any {
+	return bindOut(dest, false)
+}
+
+// bindOut cria o parâmetro OUT (ou IN OUT, com in) que grava em dest.
+func bindOut(dest any, in bool) any {
+	switch dest.(type) {
+	case *string, **string:
+		return go_ora.Out{Dest: dest, Size: 4000, In: in}
+	}
+	return sql.Out{Dest: dest, In: in}
+}
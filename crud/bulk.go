@@ -0,0 +1,186 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// bulkChunkSize limita o número de linhas enviadas em um único execute.
+const bulkChunkSize = 5000
+
+// RowError é a falha de uma linha específica de CreateMany.
+type RowError struct {
+	Index int
+	Err   error
+}
+
+// BatchError reúne as falhas por linha de CreateMany; as demais linhas foram gravadas.
+type BatchError struct {
+	Rows []RowError
+}
+
+func (e *BatchError) Error() string {
+	parts := make([]string, 0, len(e.Rows))
+	for _, r := range e.Rows {
+		parts = append(parts, fmt.Sprintf("linha %d: %v", r.Index, r.Err))
+	}
+	return fmt.Sprintf("%d linha(s) com erro: %s", len(e.Rows), strings.Join(parts, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Rows))
+	for i, r := range e.Rows {
+		errs[i] = r.Err
+	}
+	return errs
+}
+
+// CreateMany insere os elementos de models (slice de struct) usando array
+// binding do go-ora: um execute por bloco de até bulkChunkSize linhas. IDs de
+// pk com sequence são pré-alocados em uma única consulta e gravados de volta
+// nos elementos do slice. Se o execute em lote falha, o bloco é desfeito e
+// reenviado linha a linha, e as falhas são devolvidas em um *BatchError, com ID zero nas
+// posições correspondentes. Para tudo-ou-nada, use dentro de RunInTx.
+func (c *Crud) CreateMany(ctx context.Context, table string, models any) ([]int64, error) {
+	table, err := c.table(table)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(models)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("models deve ser slice de struct")
+	}
+
+	meta, err := metaOf(v.Type().Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	n := v.Len()
+	if n == 0 {
+		return nil, nil
+	}
+
+	stmts := meta.sql(table)
+
+	var ids []int64
+	if meta.PK != nil && meta.PK.Seq != "" {
+		if ids, err = c.nextSequenceValues(ctx, qualifySequence(table, meta.PK.Seq), n); err != nil {
+			return nil, err
+		}
+		for i, id := range ids {
+			if pk := v.Index(i).FieldByIndex(meta.PK.Index); pk.CanInt() {
+				pk.SetInt(id)
+			}
+		}
+	} else if meta.PK != nil {
+		ids = make([]int64, n)
+		for i := range ids {
+			if pk := v.Index(i).FieldByIndex(meta.PK.Index); pk.CanInt() {
+				ids[i] = pk.Int()
+			}
+		}
+	}
+
+	var batchErr BatchError
+
+	for start := 0; start < n; start += bulkChunkSize {
+		end := min(start+bulkChunkSize, n)
+
+		args := make([]any, len(stmts.bulkBinds))
+		for i, b := range stmts.bulkBinds {
+			// em modo array todo parâmetro precisa ser um slice
+			if b.principal {
+				principals := make([]string, end-start)
+				for row := range principals {
+					principals[row] = PrincipalFrom(ctx)
+				}
+				args[i] = principals
+				continue
+			}
+			args[i] = bulkColumn(v, start, end, b.field)
+		}
+
+		// sem batch errors (que o go-ora não suporta), o array DML para na
+		// linha com erro e mantém as anteriores: o bloco roda em uma transação
+		// própria (ou savepoint) para ser desfeito por inteiro
+		err := c.RunInTx(ctx, nil, func(tx *Crud) error {
+			_, err := tx.conn.ExecContext(ctx, stmts.insertBulk, args...)
+			return err
+		})
+		if err == nil {
+			continue
+		} else if ctx.Err() != nil {
+			return ids, err
+		}
+
+		// reenvia linha a linha para identificar quais linhas falharam
+		for row := start; row < end; row++ {
+			rowArgs := bindArgs(ctx, meta, v.Index(row), stmts.bulkBinds)
+
+			if _, err := c.conn.ExecContext(ctx, stmts.insertBulk, rowArgs...); err != nil {
+				if ctx.Err() != nil {
+					return ids, err
+				}
+				batchErr.Rows = append(batchErr.Rows, RowError{Index: row, Err: err})
+				if ids != nil {
+					ids[row] = 0
+				}
+				if meta.PK != nil && meta.PK.Seq != "" {
+					if pk := v.Index(row).FieldByIndex(meta.PK.Index); pk.CanInt() {
+						pk.SetInt(0)
+					}
+				}
+			}
+		}
+	}
+
+	failed := make(map[int]bool, len(batchErr.Rows))
+	for _, r := range batchErr.Rows {
+		failed[r.Index] = true
+	}
+	for i := 0; i < n; i++ {
+		if !failed[i] {
+			meta.initVersion(v.Index(i))
+		}
+	}
+
+	if len(batchErr.Rows) > 0 {
+		return ids, &batchErr
+	}
+	return ids, nil
+}
+
+func (c *Crud) nextSequenceValues(ctx context.Context, seq string, n int) ([]int64, error) {
+	query := fmt.Sprintf("SELECT %s.NEXTVAL FROM DUAL CONNECT BY LEVEL <= :1", seq)
+
+	rows, err := c.conn.QueryContext(ctx, query, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, n)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, translate(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, translate(err)
+	}
+
+	if len(ids) != n {
+		return nil, fmt.Errorf("sequence %s retornou %d valores, esperado %d", seq, len(ids), n)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,120 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+type bulkProduct struct {
+	ID   int64  `db:"ID,pk,seq=PRODUCTS_SEQ"`
+	Name string `db:"NAME"`
+}
+
+// bulkTable simula o array DML do Oracle sem batch errors: as linhas são
+// gravadas uma a uma e a primeira falha interrompe o execute, mantendo as
+// anteriores. Fora de transação cada execute é confirmado (autocommit).
+type bulkTable struct {
+	rows    map[int64]string
+	pending map[int64]string
+	inTx    bool
+	nextID  int64
+}
+
+func (b *bulkTable) insert(id int64, name string) error {
+	if name == "falha" {
+		return network.NewOracleError(12899)
+	}
+	if _, ok := b.rows[id]; ok {
+		return network.NewOracleError(1)
+	}
+	if _, ok := b.pending[id]; ok {
+		return network.NewOracleError(1)
+	}
+	if b.inTx {
+		b.pending[id] = name
+	} else {
+		b.rows[id] = name
+	}
+	return nil
+}
+
+func (b *bulkTable) db() *fakeDB {
+	return &fakeDB{
+		query: func(query string, args []driver.NamedValue) (driver.Rows, error) {
+			rows := &fakeRows{columns: []string{"NEXTVAL"}}
+			for range args[0].Value.(int) {
+				b.nextID++
+				rows.values = append(rows.values, []driver.Value{b.nextID})
+			}
+			return rows, nil
+		},
+		exec: func(query string, args []driver.NamedValue) (driver.Result, error) {
+			if !strings.HasPrefix(query, "INSERT") {
+				return driver.RowsAffected(0), nil
+			}
+			if ids, ok := args[0].Value.([]int64); ok {
+				names := args[1].Value.([]string)
+				for i := range ids {
+					if err := b.insert(ids[i], names[i]); err != nil {
+						return nil, err
+					}
+				}
+				return driver.RowsAffected(len(ids)), nil
+			}
+			if err := b.insert(args[0].Value.(int64), args[1].Value.(string)); err != nil {
+				return nil, err
+			}
+			return driver.RowsAffected(1), nil
+		},
+		begin: func() {
+			b.inTx = true
+			b.pending = map[int64]string{}
+		},
+		commit: func() {
+			for id, name := range b.pending {
+				b.rows[id] = name
+			}
+			b.inTx, b.pending = false, nil
+		},
+		rollback: func() {
+			b.inTx, b.pending = false, nil
+		},
+	}
+}
+
+func TestCreateManyFailureInTheMiddleOfAChunk(t *testing.T) {
+	table := &bulkTable{rows: map[int64]string{}}
+	c := NewCrud(openFake(t, table.db()), "")
+
+	products := []bulkProduct{{Name: "A"}, {Name: "B"}, {Name: "falha"}, {Name: "D"}, {Name: "E"}}
+	ids, err := c.CreateMany(context.Background(), "PRODUCTS", products)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, esperado *BatchError", err)
+	}
+	if len(batchErr.Rows) != 1 || batchErr.Rows[0].Index != 2 || !errors.Is(batchErr.Rows[0].Err, ErrValueTooLarge) {
+		t.Fatalf("falhas = %v, esperado apenas a linha 2", batchErr)
+	}
+
+	want := []int64{1, 2, 0, 4, 5}
+	for i, id := range want {
+		if ids[i] != id || products[i].ID != id {
+			t.Fatalf("linha %d: id = %d (model %d), esperado %d", i, ids[i], products[i].ID, id)
+		}
+	}
+
+	if len(table.rows) != 4 {
+		t.Fatalf("linhas gravadas = %v, esperado 4", table.rows)
+	}
+	for i, p := range products {
+		if name, ok := table.rows[p.ID]; i != 2 && (!ok || name != p.Name) {
+			t.Fatalf("linha %d não gravada: %v", i, table.rows)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	go_ora "github.com/sijms/go-ora/v2"
+)
+
+// Direções dos parâmetros de Call, informadas como opção na tag db.
+const (
+	paramIn     = "in"
+	paramOut    = "out"
+	paramInOut  = "inout"
+	paramCursor = "cursor"
+	paramReturn = "return"
+)
+
+// callParam é um campo do struct de parâmetros de Call.
+type callParam struct {
+	name      string // nome do parâmetro PL/SQL, já validado
+	field     *field
+	direction string
+}
+
+// Call executa a procedure (ou função) PL/SQL name, no formato PROC,
+// PKG.PROC ou SCHEMA.PKG.PROC, com os parâmetros descritos pelas tags db de
+// params (ponteiro para struct, ou nil quando não há parâmetros):
+//
+//	type TotalParams struct {
+//		ID    int64             `db:"P_ID,in"`
+//		Total float64           `db:"P_TOTAL,out"`
+//		Qty   int64             `db:"P_QTY,inout"`
+//		Items []models.Product  `db:"P_ITEMS,cursor"`
+//		Ok    int64             `db:",return"`
+//	}
+//
+// Os parâmetros são passados por nome (P_ID => :1), então a ordem dos campos
+// não importa. Saídas out, inout e return são gravadas de volta em params; um
+// SYS_REFCURSOR (`cursor`) é lido por completo no slice do campo, com o mesmo
+// mapeamento de ListStruct. O nome não é qualificado com o schema do Crud.
+func (c *Crud) Call(ctx context.Context, name string, params any) error {
+	name, err := routineName(name)
+	if err != nil {
+		return err
+	}
+
+	var v reflect.Value
+	var callParams []callParam
+	if params != nil {
+		v = reflect.ValueOf(params)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("params deve ser ponteiro para struct")
+		}
+		v = v.Elem()
+
+		if callParams, err = callParamsOf(v.Type()); err != nil {
+			return err
+		}
+	}
+
+	var (
+		named   []string
+		args    []any
+		result  string
+		cursors = map[*field]*go_ora.RefCursor{}
+	)
+
+	// o retorno de função ocupa o primeiro bind
+	for _, p := range callParams {
+		if p.direction == paramReturn {
+			args = append(args, outParam(v.FieldByIndex(p.field.Index).Addr().Interface()))
+			result = ":1 := "
+		}
+	}
+
+	for _, p := range callParams {
+		var arg any
+		switch p.direction {
+		case paramReturn:
+			continue
+		case paramIn:
+			arg = p.field.bindValue(v)
+		case paramOut:
+			arg = bindOut(v.FieldByIndex(p.field.Index).Addr().Interface(), false)
+		case paramInOut:
+			arg = bindOut(v.FieldByIndex(p.field.Index).Addr().Interface(), true)
+		case paramCursor:
+			cursor := &go_ora.RefCursor{}
+			cursors[p.field] = cursor
+			arg = bindOut(cursor, false)
+		}
+
+		args = append(args, arg)
+		named = append(named, fmt.Sprintf("%s => :%d", p.name, len(args)))
+	}
+
+	block := fmt.Sprintf("BEGIN %s%s(%s); END;", result, name, strings.Join(named, ", "))
+
+	// o cursor só pode ser lido na sessão que o abriu: fora de transação,
+	// reserva uma conexão do pool até terminar a leitura
+	conn := c.conn
+	if len(cursors) > 0 && c.tx == nil {
+		dbConn, err := c.db.Conn(ctx)
+		if err != nil {
+			return translate(err)
+		}
+		defer dbConn.Close()
+		conn = translatingExecutor{dbConn}
+	}
+
+	if _, err := conn.ExecContext(ctx, block, args...); err != nil {
+		return err
+	}
+
+	for f, cursor := range cursors {
+		if err := scanCursor(ctx, conn, cursor, v.FieldByIndex(f.Index)); err != nil {
+			return fmt.Errorf("cursor %s: %w", f.Column, err)
+		}
+	}
+
+	return nil
+}
+
+func scanCursor(ctx context.Context, conn executor, cursor *go_ora.RefCursor, dest reflect.Value) error {
+	meta, err := metaOf(dest.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	rows, err := go_ora.WrapRefCursor(ctx, conn, cursor)
+	if err != nil {
+		return translate(err)
+	}
+	defer rows.Close()
+
+	dest.SetLen(0)
+	return scanRows(rows, meta, dest)
+}
+
+// routineName valida nomes de rotina PL/SQL com até três partes.
+func routineName(name string) (string, error) {
+	parts := splitQualified(name)
+	if len(parts) > 3 {
+		return "", &IdentifierError{Name: name, Reason: "deve ter no máximo schema, pacote e rotina"}
+	}
+
+	for i, p := range parts {
+		id, err := identifier(p)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = id
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+func callParamsOf(t reflect.Type) ([]callParam, error) {
+	var params []callParam
+	returns := 0
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, options := parseTag(tag)
+		f := &field{Name: sf.Name, Column: name, Index: sf.Index, Options: options}
+
+		direction := paramIn
+		for _, d := range []string{paramOut, paramInOut, paramCursor, paramReturn} {
+			if f.has(d) {
+				direction = d
+			}
+		}
+
+		switch direction {
+		case paramReturn:
+			returns++
+			if returns > 1 {
+				return nil, fmt.Errorf("%s: apenas um campo pode ter return", t)
+			}
+			params = append(params, callParam{field: f, direction: direction})
+			continue
+		case paramCursor:
+			if sf.Type.Kind() != reflect.Slice || sf.Type.Elem().Kind() != reflect.Struct {
+				return nil, fmt.Errorf("%s.%s: cursor exige slice de struct", t, sf.Name)
+			}
+		}
+
+		id, err := identifier(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t, sf.Name, err)
+		}
+
+		params = append(params, callParam{name: id, field: f, direction: direction})
+	}
+
+	return params, nil
+}
@@ -0,0 +1,175 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition é um filtro de WHERE composto a partir dos construtores deste
+// arquivo. Valores sempre viram bind variables (:1, :2, ...) e colunas são
+// validadas contra as tags db do model antes de qualquer SQL ser montado.
+type Condition interface {
+	render(b *condBuilder) (string, error)
+}
+
+type condBuilder struct {
+	meta *modelMeta
+	args []any
+}
+
+func (b *condBuilder) bind(value any) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf(":%d", len(b.args))
+}
+
+func (b *condBuilder) column(name string) (string, error) {
+	if f := b.meta.fieldByColumn(name); f != nil {
+		return f.Column, nil
+	}
+	return "", fmt.Errorf("%w: %q não mapeada em %s", ErrInvalidColumn, name, b.meta.Type)
+}
+
+// where renderiza cond como cláusula WHERE, numerando os binds a partir de args.
+func where(meta *modelMeta, cond Condition, args []any) (string, []any, error) {
+	if cond == nil {
+		return "", args, nil
+	}
+
+	b := &condBuilder{meta: meta, args: args}
+	sql, err := cond.render(b)
+	if err != nil {
+		return "", nil, err
+	}
+	if sql == "" {
+		return "", args, nil
+	}
+
+	return " WHERE " + sql, b.args, nil
+}
+
+type comparison struct {
+	column string
+	op     string
+	value  any
+}
+
+func (c comparison) render(b *condBuilder) (string, error) {
+	column, err := b.column(c.column)
+	if err != nil {
+		return "", err
+	}
+	if c.op == "LIKE" {
+		return fmt.Sprintf("%s LIKE %s ESCAPE '\\'", column, b.bind(c.value)), nil
+	}
+	return fmt.Sprintf("%s %s %s", column, c.op, b.bind(c.value)), nil
+}
+
+func Eq(column string, value any) Condition { return comparison{column, "=", value} }
+func Ne(column string, value any) Condition { return comparison{column, "<>", value} }
+func Lt(column string, value any) Condition { return comparison{column, "<", value} }
+func Le(column string, value any) Condition { return comparison{column, "<=", value} }
+func Gt(column string, value any) Condition { return comparison{column, ">", value} }
+func Ge(column string, value any) Condition { return comparison{column, ">=", value} }
+
+// Like usa '\' como caractere de escape; veja EscapeLike para valores vindos do usuário.
+func Like(column string, pattern string) Condition { return comparison{column, "LIKE", pattern} }
+
+// EscapeLike escapa os curingas % e _ para busca literal com Like.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+type between struct {
+	column    string
+	low, high any
+}
+
+func (c between) render(b *condBuilder) (string, error) {
+	column, err := b.column(c.column)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s BETWEEN %s AND %s", column, b.bind(c.low), b.bind(c.high)), nil
+}
+
+func Between(column string, low, high any) Condition { return between{column, low, high} }
+
+type in struct {
+	column string
+	values []any
+}
+
+func (c in) render(b *condBuilder) (string, error) {
+	column, err := b.column(c.column)
+	if err != nil {
+		return "", err
+	}
+
+	// IN () é inválido no Oracle; lista vazia nunca casa
+	if len(c.values) == 0 {
+		return "1 = 0", nil
+	}
+	if len(c.values) > 1000 {
+		return "", fmt.Errorf("%w: IN com mais de 1000 valores (ORA-01795)", ErrInvalidCondition)
+	}
+
+	binds := make([]string, len(c.values))
+	for i, v := range c.values {
+		binds[i] = b.bind(v)
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(binds, ", ")), nil
+}
+
+func In(column string, values ...any) Condition { return in{column, values} }
+
+type nullCheck struct {
+	column string
+	not    bool
+}
+
+func (c nullCheck) render(b *condBuilder) (string, error) {
+	column, err := b.column(c.column)
+	if err != nil {
+		return "", err
+	}
+	if c.not {
+		return column + " IS NOT NULL", nil
+	}
+	return column + " IS NULL", nil
+}
+
+func IsNull(column string) Condition    { return nullCheck{column, false} }
+func IsNotNull(column string) Condition { return nullCheck{column, true} }
+
+type group struct {
+	op    string
+	conds []Condition
+}
+
+func (g group) render(b *condBuilder) (string, error) {
+	parts := make([]string, 0, len(g.conds))
+	for _, c := range g.conds {
+		if c == nil {
+			continue
+		}
+		sql, err := c.render(b)
+		if err != nil {
+			return "", err
+		}
+		if sql != "" {
+			parts = append(parts, sql)
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return "", nil
+	case 1:
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, " "+g.op+" ") + ")", nil
+}
+
+// And e Or ignoram condições nil, o que permite montar filtros opcionais.
+func And(conds ...Condition) Condition { return group{"AND", conds} }
+func Or(conds ...Condition) Condition  { return group{"OR", conds} }
@@ -0,0 +1,105 @@
+package crud
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhere(t *testing.T) {
+	meta, err := metaOf(reflect.TypeFor[seekProduct]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thousand := make([]any, 1000)
+	for i := range thousand {
+		thousand[i] = i
+	}
+
+	tests := []struct {
+		name     string
+		cond     Condition
+		start    []any
+		want     string
+		wantArgs []any
+		wantErr  error
+	}{
+		{name: "nil", cond: nil, want: ""},
+		{name: "comparação", cond: Eq("NAME", "A"), want: " WHERE NAME = :1", wantArgs: []any{"A"}},
+		{name: "minúsculas", cond: Ge("price", 2.0), want: " WHERE PRICE >= :1", wantArgs: []any{2.0}},
+		{
+			name:     "numeração a partir de args",
+			cond:     Lt("ID", 9),
+			start:    []any{"x", "y"},
+			want:     " WHERE ID < :3",
+			wantArgs: []any{"x", "y", 9},
+		},
+		{
+			name:     "and/or aninhados",
+			cond:     And(Eq("NAME", "A"), Or(Lt("PRICE", 1), Gt("PRICE", 9)), nil),
+			want:     " WHERE (NAME = :1 AND (PRICE < :2 OR PRICE > :3))",
+			wantArgs: []any{"A", 1, 9},
+		},
+		{name: "and vazio", cond: And(nil, nil), want: ""},
+		{name: "and com um item", cond: And(nil, Ne("ID", 1)), want: " WHERE ID <> :1", wantArgs: []any{1}},
+		{
+			name:     "between",
+			cond:     Between("PRICE", 1, 2),
+			want:     " WHERE PRICE BETWEEN :1 AND :2",
+			wantArgs: []any{1, 2},
+		},
+		{
+			name:     "like",
+			cond:     Like("NAME", "%"+EscapeLike("50%_off")+"%"),
+			want:     ` WHERE NAME LIKE :1 ESCAPE '\'`,
+			wantArgs: []any{`%50\%\_off%`},
+		},
+		{name: "in vazio", cond: In("ID"), want: " WHERE 1 = 0"},
+		{name: "in", cond: In("ID", 1, 2, 3), want: " WHERE ID IN (:1, :2, :3)", wantArgs: []any{1, 2, 3}},
+		{name: "in com 1000 valores", cond: In("ID", thousand...), wantArgs: thousand},
+		{name: "in com mais de 1000 valores", cond: In("ID", append(thousand, 1000)...), wantErr: ErrInvalidCondition},
+		{name: "is null", cond: IsNull("NAME"), want: " WHERE NAME IS NULL"},
+		{name: "is not null", cond: IsNotNull("NAME"), want: " WHERE NAME IS NOT NULL"},
+		{name: "coluna desconhecida", cond: Eq("SENHA", 1), wantErr: ErrInvalidColumn},
+		{name: "injeção na coluna", cond: Eq("NAME = NAME OR 1", 1), wantErr: ErrInvalidColumn},
+		{name: "coluna inválida aninhada", cond: Or(Eq("NAME", 1), IsNull("X")), wantErr: ErrInvalidColumn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := where(meta, tt.cond, tt.start)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, esperado %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want != "" && got != tt.want {
+				t.Fatalf("SQL = %q, esperado %q", got, tt.want)
+			}
+			if len(args) != len(tt.wantArgs) || len(args) > 0 && !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("args = %v, esperado %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"abc":    "abc",
+		"50%":    `50\%`,
+		"a_b":    `a\_b`,
+		`c:\dir`: `c:\\dir`,
+		`\%_`:    `\\\%\_`,
+		"":       "",
+	}
+	for in, want := range tests {
+		if got := EscapeLike(in); got != want {
+			t.Errorf("EscapeLike(%q) = %q, esperado %q", in, got, want)
+		}
+	}
+}
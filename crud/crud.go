@@ -1,432 +1,340 @@
 package crud
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
-	"strings"
 )
 
+// executor é o conjunto de operações comum a *sql.DB e *sql.Tx.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 type Crud struct {
 	db     *sql.DB
+	conn   executor
+	tx     *sql.Tx
+	depth  int
 	schema string
+	retry  *RetryPolicy
 }
 
 func NewCrud(db *sql.DB, schema string) *Crud {
-	return &Crud{db: db, schema: schema}
+	return &Crud{db: db, conn: translatingExecutor{db}, schema: schema}
 }
 
 func StructToMap(input any) map[string]any {
-	result := make(map[string]any)
-	v := reflect.ValueOf(input)
-	t := reflect.TypeOf(input)
-
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		t = t.Elem()
+	v, meta, err := modelValue(input)
+	if err != nil {
+		return map[string]any{}
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
-		tag := field.Tag.Get("db")
-		if tag == "" {
+	result := make(map[string]any, len(meta.Fields))
+	for _, f := range meta.Fields {
+		if f.PK {
 			continue
 		}
-
-		parts := strings.Split(tag, ",")
-		column := parts[0]
-
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				goto skip
-			}
-		}
-
-		result[column] = value.Interface()
-	skip:
+		result[f.Column] = meta.value(v, f)
 	}
 
 	return result
 }
 
 func (c *Crud) CreateStruct(table string, model any) error {
-	v := reflect.ValueOf(model)
-	t := reflect.TypeOf(model)
+	return c.CreateStructContext(context.Background(), table, model)
+}
 
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		t = t.Elem()
+// CreateStructContext insere model. Quando model é ponteiro, a pk gerada e as
+// colunas preenchidas pelo banco são gravadas nele na mesma ida ao banco.
+func (c *Crud) CreateStructContext(ctx context.Context, table string, model any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
 	}
 
-	var columns []string
-	var values []string
-	var args []any
-
-	argIndex := 1
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
-
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
-
-		parts := strings.Split(tag, ",")
-		column := parts[0]
-
-		var isPK bool
-		var seq string
-
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				isPK = true
-			}
-			if strings.HasPrefix(p, "seq=") {
-				seq = strings.TrimPrefix(p, "seq=")
-			}
-		}
-
-		// 🔑 PK com sequence
-		if isPK && seq != "" {
-			columns = append(columns, column)
-			values = append(values, seq+".NEXTVAL")
-			continue
-		}
-
-		// ignora PK sem sequence
-		if isPK {
-			continue
-		}
-
-		columns = append(columns, column)
-		values = append(values, fmt.Sprintf(":%d", argIndex))
-		args = append(args, value.Interface())
-		argIndex++
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
 	}
 
-	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s)",
-		table,
-		strings.Join(columns, ", "),
-		strings.Join(values, ", "),
-	)
-
-	_, err := c.db.Exec(query, args...)
-	return err
+	return c.insert(ctx, table, v, meta)
 }
 
-func (c *Crud) CreateStructReturningID(table string, model any, idDest *int64) error {
-	v := reflect.ValueOf(model)
-	t := reflect.TypeOf(model)
+// CreateStructReturningID insere model e grava a pk gerada em idDest, ponteiro
+// para um tipo compatível com o campo da pk.
+func (c *Crud) CreateStructReturningID(table string, model any, idDest any) error {
+	return c.CreateStructReturningIDContext(context.Background(), table, model, idDest)
+}
 
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		t = t.Elem()
+func (c *Crud) CreateStructReturningIDContext(ctx context.Context, table string, model any, idDest any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
 	}
 
-	var columns []string
-	var values []string
-	var args []any
-
-	argIndex := 1
-	var hasReturning bool
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
-
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
 
-		parts := strings.Split(tag, ",")
-		column := parts[0]
+	dest := reflect.ValueOf(idDest)
+	if dest.Kind() != reflect.Ptr || dest.IsNil() {
+		return fmt.Errorf("idDest deve ser ponteiro não nil")
+	}
 
-		var isPK bool
-		var seq string
+	// model recebido por valor: o RETURNING precisa de uma cópia endereçável
+	if !v.CanAddr() {
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
 
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				isPK = true
-			}
-			if strings.HasPrefix(p, "seq=") {
-				seq = strings.TrimPrefix(p, "seq=")
-			}
-		}
+	if err := c.insert(ctx, table, v, meta); err != nil {
+		return err
+	}
 
-		// PK com sequence
-		if isPK && seq != "" {
-			columns = append(columns, column)
-			values = append(values, seq+".NEXTVAL")
-			hasReturning = true
-			continue
-		}
+	return setKey(dest.Elem(), v.FieldByIndex(meta.PK.Index), meta.PK)
+}
 
-		if isPK {
-			continue
-		}
+func (c *Crud) insert(ctx context.Context, table string, v reflect.Value, meta *modelMeta) error {
+	stmts := meta.sql(table)
 
-		columns = append(columns, column)
-		values = append(values, fmt.Sprintf(":%d", argIndex))
-		args = append(args, value.Interface())
-		argIndex++
-	}
+	args := bindArgs(ctx, meta, v, stmts.insertBinds)
+	args = append(args, outArgs(v, stmts.insertOut)...)
 
-	// OUT parameter
-	if hasReturning {
-		args = append(args, sql.Out{Dest: idDest})
+	if _, err := c.conn.ExecContext(ctx, stmts.insert, args...); err != nil {
+		return err
 	}
 
-	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s) RETURNING ID INTO :%d",
-		table,
-		strings.Join(columns, ", "),
-		strings.Join(values, ", "),
-		argIndex,
-	)
-
-	_, err := c.db.Exec(query, args...)
-	return err
+	meta.initVersion(v)
+	return nil
 }
 
 func (c *Crud) UpdateStruct(table string, model any) error {
-	v := reflect.ValueOf(model)
-	t := reflect.TypeOf(model)
+	return c.UpdateStructContext(context.Background(), table, model)
+}
 
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-		t = t.Elem()
+func (c *Crud) UpdateStructContext(ctx context.Context, table string, model any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
 	}
 
-	var sets []string
-	var args []any
-
-	var pkColumn string
-	var pkValue any
-	argIndex := 1
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
 
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
+	stmts := meta.sql(table)
+	if stmts.update == "" {
+		return fmt.Errorf("%w: %s não tem colunas além da pk para atualizar", ErrInvalidColumn, meta.Type)
+	}
 
-		parts := strings.Split(tag, ",")
-		column := parts[0]
+	args := bindArgs(ctx, meta, v, stmts.updateBinds)
+	args = append(args, meta.pkValues(v)...)
 
-		var isPK bool
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				isPK = true
-			}
+	if meta.Version == nil {
+		args = append(args, outArgs(v, stmts.updateOut)...)
+		result, err := c.conn.ExecContext(ctx, stmts.update, args...)
+		if err != nil {
+			return err
 		}
+		return affectedOne(result)
+	}
 
-		if isPK {
-			pkColumn = column
-			pkValue = value.Interface()
-			continue
-		}
+	args = append(args, meta.value(v, meta.Version))
+	args = append(args, outArgs(v, stmts.updateOut)...)
 
-		sets = append(sets, fmt.Sprintf("%s = :%d", column, argIndex))
-		args = append(args, value.Interface())
-		argIndex++
+	result, err := c.conn.ExecContext(ctx, stmts.update, args...)
+	if err != nil {
+		return err
 	}
-
-	if pkColumn == "" {
-		return fmt.Errorf("pk não encontrada no model")
+	if err := c.checkVersioned(ctx, table, meta, v, result); err != nil {
+		return err
 	}
 
-	args = append(args, pkValue)
-
-	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s = :%d",
-		table,
-		strings.Join(sets, ", "),
-		pkColumn,
-		argIndex,
-	)
-
-	_, err := c.db.Exec(query, args...)
-	return err
+	meta.bumpVersion(v)
+	return nil
 }
 
-func (c *Crud) DeleteByID(table string, pkColumn string, id any) error {
-	query := fmt.Sprintf(
-		"DELETE FROM %s WHERE %s = :1",
-		table,
-		pkColumn,
-	)
-	_, err := c.db.Exec(query, id)
-	return err
+// DeleteByKey remove a linha de chave key (veja ApplyKey). model (valor ou
+// ponteiro) só informa o tipo, e com ele as regras de DeleteByPK: models com
+// `softdelete` são excluídos logicamente. Substitui o antigo
+// DeleteByID(table, pkColumn, id), que recebia a coluna da pk como texto.
+func (c *Crud) DeleteByKey(table string, model any, key any) error {
+	return c.DeleteByKeyContext(context.Background(), table, model, key)
 }
 
-func (c *Crud) DeleteByPK(table string, model any) error {
-	v := reflect.ValueOf(model)
+func (c *Crud) DeleteByKeyContext(ctx context.Context, table string, model any, key any) error {
 	t := reflect.TypeOf(model)
-
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+	for t != nil && t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
-
-	var pkColumn string
-	var pkValue any
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := v.Field(i)
-
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
-
-		parts := strings.Split(tag, ",")
-		column := parts[0]
-
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				pkColumn = column
-				pkValue = value.Interface()
-				break
-			}
-		}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("model deve ser struct ou ponteiro para struct")
 	}
 
-	if pkColumn == "" {
-		return fmt.Errorf("pk não encontrada no model")
+	keyed := reflect.New(t).Interface()
+	if err := ApplyKey(keyed, key); err != nil {
+		return err
 	}
+	return c.DeleteByPKContext(ctx, table, keyed)
+}
 
-	query := fmt.Sprintf(
-		"DELETE FROM %s WHERE %s = :1",
-		table,
-		pkColumn,
-	)
-
-	_, err := c.db.Exec(query, pkValue)
-	return err
+func (c *Crud) DeleteByPK(table string, model any) error {
+	return c.DeleteByPKContext(context.Background(), table, model)
 }
 
-func (c *Crud) ListStruct(table string, dest any) error {
-	v := reflect.ValueOf(dest)
-	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
-		return fmt.Errorf("dest deve ser ponteiro para slice")
+func (c *Crud) DeleteByPKContext(ctx context.Context, table string, model any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
 	}
 
-	sliceValue := v.Elem()
-	elemType := sliceValue.Type().Elem()
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
 
-	var columns []string
-	var scanTargets []any
+	stmts := meta.sql(table)
+	args := append(bindArgs(ctx, meta, v, stmts.deleteBinds), meta.pkValues(v)...)
 
-	for i := 0; i < elemType.NumField(); i++ {
-		field := elemType.Field(i)
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
+	// versão zero significa que o chamador não conhece a versão: sem verificação
+	if meta.Version == nil || v.FieldByIndex(meta.Version.Index).IsZero() {
+		result, err := c.conn.ExecContext(ctx, stmts.deleteByPK, args...)
+		if err != nil {
+			return err
 		}
+		return affectedOne(result)
+	}
 
-		column := strings.Split(tag, ",")[0]
-		columns = append(columns, column)
+	args = append(args, meta.value(v, meta.Version))
+	result, err := c.conn.ExecContext(ctx, stmts.deleteByPKVersioned, args...)
+	if err != nil {
+		return err
 	}
+	return c.checkVersioned(ctx, table, meta, v, result)
+}
 
-	query := fmt.Sprintf(
-		"SELECT %s FROM %s ORDER BY %s",
-		strings.Join(columns, ", "),
-		table,
-		columns[0],
-	)
+func (c *Crud) ListStruct(table string, dest any) error {
+	return c.ListStructContext(context.Background(), table, dest)
+}
 
-	rows, err := c.db.Query(query)
+func (c *Crud) ListStructContext(ctx context.Context, table string, dest any, opts ...QueryOption) error {
+	table, err := c.table(table)
 	if err != nil {
 		return err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		elemPtr := reflect.New(elemType)
-		elem := elemPtr.Elem()
-
-		scanTargets = scanTargets[:0]
-
-		for i := 0; i < elemType.NumField(); i++ {
-			field := elemType.Field(i)
-			tag := field.Tag.Get("db")
-			if tag == "" {
-				continue
-			}
-			scanTargets = append(scanTargets, elem.Field(i).Addr().Interface())
-		}
+	sliceValue, meta, err := sliceDest(dest)
+	if err != nil {
+		return err
+	}
 
-		if err := rows.Scan(scanTargets...); err != nil {
+	query := meta.sql(table).selectAll
+	var args []any
+	if len(opts) > 0 {
+		query, args, err = buildSelect(meta, table, nil, newQueryOptions(opts))
+		if err != nil {
 			return err
 		}
+	}
 
-		sliceValue.Set(reflect.Append(sliceValue, elem))
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
-	return nil
+	return scanRows(rows, meta, sliceValue)
 }
 
 func (c *Crud) FindByID(table string, dest any) error {
+	return c.FindByIDContext(context.Background(), table, dest)
+}
+
+func (c *Crud) FindByIDContext(ctx context.Context, table string, dest any, opts ...QueryOption) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
 	v := reflect.ValueOf(dest)
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("dest deve ser ponteiro para struct")
 	}
 
 	elem := v.Elem()
-	elemType := elem.Type()
+	meta, err := metaOf(elem.Type())
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
 
-	var columns []string
-	var scanTargets []any
+	query := meta.sql(table).selectByPK
+	if newQueryOptions(opts).includeDeleted {
+		query = meta.sql(table).selectByPKAll
+	}
 
-	var pkColumn string
-	var pkValue any
+	return c.queryRow(ctx, query, meta.pkValues(elem), meta.scanTargets(elem, make([]any, 0, len(meta.Fields)))...)
+}
 
-	for i := 0; i < elemType.NumField(); i++ {
-		field := elemType.Field(i)
-		tag := field.Tag.Get("db")
-		if tag == "" {
-			continue
-		}
+// sliceDest valida que dest é ponteiro para slice de struct e devolve o slice e o schema do elemento.
+func sliceDest(dest any) (reflect.Value, *modelMeta, error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, nil, fmt.Errorf("dest deve ser ponteiro para slice")
+	}
 
-		parts := strings.Split(tag, ",")
-		column := parts[0]
+	sliceValue := v.Elem()
+	if sliceValue.Type().Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("dest deve ser ponteiro para slice de struct")
+	}
 
-		var isPK bool
-		for _, p := range parts[1:] {
-			if p == "pk" {
-				isPK = true
-			}
-		}
+	meta, err := metaOf(sliceValue.Type().Elem())
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	return sliceValue, meta, nil
+}
+
+// scanRows lê todas as linhas de rows para dentro de sliceValue.
+func scanRows(rows *sql.Rows, meta *modelMeta, sliceValue reflect.Value) error {
+	elemType := sliceValue.Type().Elem()
+	scanTargets := make([]any, 0, len(meta.Fields))
 
-		if isPK {
-			pkColumn = column
-			pkValue = elem.Field(i).Interface()
+	// escaneia direto no elemento do slice para evitar uma cópia da struct por linha
+	for rows.Next() {
+		n := sliceValue.Len()
+		if n == sliceValue.Cap() {
+			sliceValue.Grow(1)
 		}
+		sliceValue.SetLen(n + 1)
 
-		columns = append(columns, column)
-		scanTargets = append(scanTargets, elem.Field(i).Addr().Interface())
-	}
+		elem := sliceValue.Index(n)
+		elem.Set(reflect.Zero(elemType))
 
-	if pkColumn == "" {
-		return fmt.Errorf("pk não encontrada no model")
+		if err := rows.Scan(meta.scanTargets(elem, scanTargets)...); err != nil {
+			sliceValue.SetLen(n)
+			return translate(err)
+		}
 	}
 
-	query := fmt.Sprintf(
-		"SELECT %s FROM %s WHERE %s = :1",
-		strings.Join(columns, ", "),
-		table,
-		pkColumn,
-	)
-
-	row := c.db.QueryRow(query, pkValue)
-	return row.Scan(scanTargets...)
+	return translate(rows.Err())
 }
@@ -0,0 +1,105 @@
+package crud
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const benchRows = 10000
+
+type benchProduct struct {
+	ID    int64   `db:"ID,pk"`
+	Name  string  `db:"NAME"`
+	Price float64 `db:"PRICE"`
+	Stock int64   `db:"STOCK"`
+}
+
+// benchDB devolve sempre as mesmas n linhas para qualquer consulta.
+func benchDB(n int) *fakeDB {
+	values := make([][]driver.Value, n)
+	for i := range values {
+		values[i] = []driver.Value{int64(i + 1), fmt.Sprintf("Produto %d", i+1), float64(i) * 1.5, int64(i % 100)}
+	}
+
+	return &fakeDB{
+		query: func(string, []driver.NamedValue) (driver.Rows, error) {
+			return &fakeRows{columns: []string{"ID", "NAME", "PRICE", "STOCK"}, values: values}, nil
+		},
+	}
+}
+
+func BenchmarkListStruct(b *testing.B) {
+	c := NewCrud(openFake(b, benchDB(benchRows)), "")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var dest []benchProduct
+		if err := c.ListStruct("PRODUCTS", &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkListStructLegacy mede a implementação anterior ao cache de
+// metadados, como referência para BenchmarkListStruct.
+func BenchmarkListStructLegacy(b *testing.B) {
+	db := openFake(b, benchDB(benchRows))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var dest []benchProduct
+		if err := legacyListStruct(db, "PRODUCTS", &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// legacyListStruct reproduz o ListStruct original: tags lidas a cada chamada
+// e a cada linha, e uma struct nova anexada ao slice por linha.
+func legacyListStruct(db *sql.DB, table string, dest any) error {
+	sliceValue := reflect.ValueOf(dest).Elem()
+	elemType := sliceValue.Type().Elem()
+
+	var columns []string
+	var scanTargets []any
+
+	for i := 0; i < elemType.NumField(); i++ {
+		tag := elemType.Field(i).Tag.Get("db")
+		if tag == "" {
+			continue
+		}
+		columns = append(columns, strings.Split(tag, ",")[0])
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY %s", strings.Join(columns, ", "), table, columns[0])
+
+	rows, err := db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		elem := reflect.New(elemType).Elem()
+
+		scanTargets = scanTargets[:0]
+		for i := 0; i < elemType.NumField(); i++ {
+			if elemType.Field(i).Tag.Get("db") == "" {
+				continue
+			}
+			scanTargets = append(scanTargets, elem.Field(i).Addr().Interface())
+		}
+
+		if err := rows.Scan(scanTargets...); err != nil {
+			return err
+		}
+
+		sliceValue.Set(reflect.Append(sliceValue, elem))
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,86 @@
+package crud
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// Cursor guarda os valores das colunas de ordenação da linha na borda de uma
+// página de keyset. Backward indica navegação para a página anterior. Order e
+// Filter registram a ordenação e a condição da consulta que gerou o cursor;
+// FindSeek rejeita o cursor em uma consulta diferente.
+type Cursor struct {
+	Keys     []any    `json:"k"`
+	Backward bool     `json:"b,omitempty"`
+	Order    []string `json:"o,omitempty"`
+	Filter   string   `json:"f,omitempty"`
+}
+
+func (c Cursor) IsZero() bool {
+	return len(c.Keys) == 0
+}
+
+// CursorCodec serializa cursores em tokens opacos assinados com HMAC-SHA256,
+// impedindo que o cliente forje valores de chave.
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec usa uma chave aleatória quando secret é vazio; nesse caso os
+// tokens deixam de valer quando o processo reinicia.
+func NewCursorCodec(secret []byte) *CursorCodec {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+	return &CursorCodec{secret: secret}
+}
+
+func (c *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(c.sign(payload)), nil
+}
+
+func (c *CursorCodec) Decode(token string) (Cursor, error) {
+	var cursor Cursor
+
+	enc := base64.RawURLEncoding
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return cursor, ErrInvalidCursor
+	}
+
+	payload, err := enc.DecodeString(encodedPayload)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	sig, err := enc.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, c.sign(payload)) {
+		return cursor, ErrInvalidCursor
+	}
+
+	// UseNumber preserva IDs grandes; FindSeek converte para o tipo do campo
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&cursor); err != nil || cursor.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
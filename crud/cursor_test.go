@@ -0,0 +1,64 @@
+package crud
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCursorCodecRoundTrip(t *testing.T) {
+	codec := NewCursorCodec([]byte("segredo"))
+	cursor := Cursor{Keys: []any{"Caneta", int64(9007199254740993)}, Backward: true, Order: []string{"NAME", "ID"}, Filter: "abc"}
+
+	token, err := codec.Encode(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := codec.Decode(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Backward || got.Filter != "abc" || strings.Join(got.Order, ",") != "NAME,ID" {
+		t.Fatalf("cursor decodificado = %+v", got)
+	}
+	// IDs grandes não podem perder precisão no float64 do JSON
+	if n, ok := got.Keys[1].(json.Number); !ok || n.String() != "9007199254740993" {
+		t.Fatalf("chave = %#v", got.Keys[1])
+	}
+}
+
+func TestCursorCodecRejectsInvalidTokens(t *testing.T) {
+	codec := NewCursorCodec([]byte("segredo"))
+	token, err := codec.Encode(Cursor{Keys: []any{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+
+	other, err := NewCursorCodec([]byte("outro")).Encode(Cursor{Keys: []any{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, err := codec.Encode(Cursor{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"vazio":             "",
+		"sem assinatura":    payload,
+		"payload alterado":  payload + "x." + sig,
+		"assinatura errada": other,
+		"base64 inválido":   "!!!." + sig,
+		"sem chaves":        empty,
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := codec.Decode(token); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("Decode(%q) = %v, esperado ErrInvalidCursor", token, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package crud
+
+import "errors"
+
+var (
+	ErrInvalidColumn     = errors.New("coluna inválida")
+	ErrInvalidCondition  = errors.New("condição inválida")
+	ErrInvalidCursor     = errors.New("cursor inválido")
+	ErrInvalidIdentifier = errors.New("identificador inválido")
+	ErrInvalidSort       = errors.New("ordenação inválida")
+	ErrStaleObject       = errors.New("registro alterado por outra transação")
+)
+
+// Categorias dos erros do banco; veja DBError.
+var (
+	ErrNotFound      = errors.New("registro não encontrado")
+	ErrDuplicate     = errors.New("registro duplicado")
+	ErrForeignKey    = errors.New("violação de chave estrangeira")
+	ErrValueTooLarge = errors.New("valor excede o tamanho da coluna")
+	ErrTimeout       = errors.New("tempo limite do banco excedido")
+	ErrConnection    = errors.New("falha de conexão com o banco")
+	ErrKeyAboveSeq   = errors.New("chave acima dos valores emitidos pela sequence")
+)
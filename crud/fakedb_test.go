@@ -0,0 +1,104 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDB é um driver database/sql em memória para testes sem Oracle: cada
+// comando é repassado aos handlers. Handlers nil aceitam o comando sem efeito.
+type fakeDB struct {
+	query    func(query string, args []driver.NamedValue) (driver.Rows, error)
+	exec     func(query string, args []driver.NamedValue) (driver.Result, error)
+	begin    func()
+	commit   func()
+	rollback func()
+}
+
+func openFake(tb testing.TB, f *fakeDB) *sql.DB {
+	db := sql.OpenDB(f)
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return fakeDriver{db: f} }
+
+type fakeDriver struct{ db *fakeDB }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{db: d.db}, nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake: prepare não suportado")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	if c.db.begin != nil {
+		c.db.begin()
+	}
+	return fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.db.query == nil {
+		return &fakeRows{}, nil
+	}
+	return c.db.query(query, args)
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.db.exec == nil {
+		return driver.RowsAffected(0), nil
+	}
+	return c.db.exec(query, args)
+}
+
+// CheckNamedValue aceita qualquer argumento, inclusive os slices do array
+// binding e os sql.Out.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error {
+	if t.db.commit != nil {
+		t.db.commit()
+	}
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	if t.db.rollback != nil {
+		t.db.rollback()
+	}
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
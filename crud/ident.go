@@ -0,0 +1,126 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxIdentifierLength é o limite de identificadores do Oracle a partir do 12.2.
+const maxIdentifierLength = 128
+
+// IdentifierError indica um identificador (tabela, schema, coluna ou sequence)
+// rejeitado antes de qualquer SQL ser montado ou executado.
+type IdentifierError struct {
+	Name   string
+	Reason string
+}
+
+func (e *IdentifierError) Error() string {
+	return fmt.Sprintf("%s: %q %s", ErrInvalidIdentifier, e.Name, e.Reason)
+}
+
+func (e *IdentifierError) Is(target error) bool {
+	return target == ErrInvalidIdentifier
+}
+
+// reservedWords são as palavras reservadas do Oracle, que não podem ser usadas
+// como identificador sem aspas.
+var reservedWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`
+		ACCESS ADD ALL ALTER AND ANY AS ASC AUDIT BETWEEN BY CHAR CHECK CLUSTER
+		COLUMN COMMENT COMPRESS CONNECT CREATE CURRENT DATE DECIMAL DEFAULT DELETE
+		DESC DISTINCT DROP ELSE EXCLUSIVE EXISTS FILE FLOAT FOR FROM GRANT GROUP
+		HAVING IDENTIFIED IMMEDIATE IN INCREMENT INDEX INITIAL INSERT INTEGER
+		INTERSECT INTO IS LEVEL LIKE LOCK LONG MAXEXTENTS MINUS MLSLABEL MODE
+		MODIFY NOAUDIT NOCOMPRESS NOT NOWAIT NULL NUMBER OF OFFLINE ON ONLINE
+		OPTION OR ORDER PCTFREE PRIOR PUBLIC RAW RENAME RESOURCE REVOKE ROW ROWID
+		ROWNUM ROWS SELECT SESSION SET SHARE SIZE SMALLINT START SUCCESSFUL
+		SYNONYM SYSDATE TABLE THEN TO TRIGGER UID UNION UNIQUE UPDATE USER
+		VALIDATE VALUES VARCHAR VARCHAR2 VIEW WHENEVER WHERE WITH`) {
+		reservedWords[w] = true
+	}
+}
+
+// identifier valida um identificador simples e devolve a forma usada no SQL.
+// Nomes entre aspas ("Nome") são mantidos como estão e diferenciam maiúsculas.
+func identifier(name string) (string, error) {
+	if strings.HasPrefix(name, `"`) {
+		if len(name) < 2 || !strings.HasSuffix(name, `"`) {
+			return "", &IdentifierError{Name: name, Reason: "com aspas não fechadas"}
+		}
+		return quoteIdentifier(name[1 : len(name)-1])
+	}
+
+	if name == "" {
+		return "", &IdentifierError{Name: name, Reason: "vazio"}
+	}
+	if len(name) > maxIdentifierLength {
+		return "", &IdentifierError{Name: name, Reason: "excede 128 caracteres"}
+	}
+	for i, r := range name {
+		letter := r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z'
+		if i == 0 && !letter {
+			return "", &IdentifierError{Name: name, Reason: "deve começar com letra"}
+		}
+		if !letter && !(r >= '0' && r <= '9') && r != '_' && r != '$' && r != '#' {
+			return "", &IdentifierError{Name: name, Reason: "contém caractere não permitido"}
+		}
+	}
+	if reservedWords[strings.ToUpper(name)] {
+		return "", &IdentifierError{Name: name, Reason: "é palavra reservada"}
+	}
+
+	return name, nil
+}
+
+// quoteIdentifier envolve name em aspas, preservando maiúsculas e minúsculas.
+func quoteIdentifier(name string) (string, error) {
+	if name == "" {
+		return "", &IdentifierError{Name: name, Reason: "vazio"}
+	}
+	if len(name) > maxIdentifierLength {
+		return "", &IdentifierError{Name: name, Reason: "excede 128 caracteres"}
+	}
+	if strings.ContainsAny(name, "\"\x00") {
+		return "", &IdentifierError{Name: name, Reason: "contém aspas ou caractere nulo"}
+	}
+	return `"` + name + `"`, nil
+}
+
+// qualifiedIdentifier valida nomes no formato OBJETO ou SCHEMA.OBJETO.
+func qualifiedIdentifier(name string) (string, error) {
+	parts := splitQualified(name)
+	if len(parts) > 2 {
+		return "", &IdentifierError{Name: name, Reason: "deve ter no máximo schema e objeto"}
+	}
+
+	for i, p := range parts {
+		id, err := identifier(p)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = id
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// splitQualified separa as partes de um nome qualificado, ignorando pontos
+// dentro de aspas.
+func splitQualified(name string) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, r := range name {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '.' && !quoted:
+			parts = append(parts, name[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, name[start:])
+}
@@ -0,0 +1,93 @@
+package crud
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	long := "A" + strings.Repeat("B", maxIdentifierLength-1)
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "PRODUCTS", want: "PRODUCTS"},
+		{name: "products", want: "products"},
+		{name: "PRODUCT_2$#", want: "PRODUCT_2$#"},
+		{name: long, want: long},
+		{name: long + "C", wantErr: true},
+		{name: "", wantErr: true},
+		{name: "2PRODUCTS", wantErr: true},
+		{name: "_PRODUCTS", wantErr: true},
+		{name: "PRODUCTS;DROP", wantErr: true},
+		{name: "PRODUCTS --", wantErr: true},
+		{name: "PRODUÇÃO", wantErr: true},
+		{name: "SELECT", wantErr: true},
+		{name: "order", wantErr: true},
+		{name: `"Order"`, want: `"Order"`},
+		{name: `"Nome Com Espaço"`, want: `"Nome Com Espaço"`},
+		{name: `"A.B"`, want: `"A.B"`},
+		{name: `"` + long + `"`, want: `"` + long + `"`},
+		{name: `"` + long + `C"`, wantErr: true},
+		{name: `""`, wantErr: true},
+		{name: `"`, wantErr: true},
+		{name: `"aberto`, wantErr: true},
+		{name: `"a"b"`, wantErr: true},
+		{name: `"a` + "\x00" + `"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := identifier(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidIdentifier) {
+					t.Fatalf("identifier(%q) = %q, %v; esperado ErrInvalidIdentifier", tt.name, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("identifier(%q) = %q, %v; esperado %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualifiedIdentifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "PRODUCTS", want: "PRODUCTS"},
+		{name: "SALES.PRODUCTS", want: "SALES.PRODUCTS"},
+		{name: `"Sales"."Products"`, want: `"Sales"."Products"`},
+		{name: `SALES."Tabela.Com.Ponto"`, want: `SALES."Tabela.Com.Ponto"`},
+		{name: `"a.b.c"`, want: `"a.b.c"`},
+		{name: "A.B.C", wantErr: true},
+		{name: ".PRODUCTS", wantErr: true},
+		{name: "SALES.", wantErr: true},
+		{name: "SALES..PRODUCTS", wantErr: true},
+		{name: "SALES.SELECT", wantErr: true},
+		{name: "SALES.PRODUCTS WHERE 1=1", wantErr: true},
+		{name: `SALES."Products`, wantErr: true},
+		{name: `"Sales".`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := qualifiedIdentifier(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidIdentifier) {
+					t.Fatalf("qualifiedIdentifier(%q) = %q, %v; esperado ErrInvalidIdentifier", tt.name, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("qualifiedIdentifier(%q) = %q, %v; esperado %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+type triggerProduct struct {
+	ID   int64  `db:"ID,pk"`
+	Name string `db:"NAME"`
+}
+
+type assignedProduct struct {
+	Code string `db:"CODE,pk,assigned"`
+	Name string `db:"NAME"`
+}
+
+func TestInsertPKSource(t *testing.T) {
+	tests := []struct {
+		name  string
+		model any
+		want  string
+	}{
+		{"pk simples preenchida pelo banco", &triggerProduct{Name: "A"}, "INSERT INTO T (NAME) VALUES (:1) RETURNING ID INTO :2"},
+		{"pk assigned", &assignedProduct{Code: "X1", Name: "A"}, "INSERT INTO T (CODE, NAME) VALUES (:1, :2)"},
+		{"pk composta", &orderItem{OrderID: 1, Line: 2, Sku: "S"}, "INSERT INTO T (ORDER_ID, LINE, SKU) VALUES (:1, :2, :3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			db := &fakeDB{
+				exec: func(q string, _ []driver.NamedValue) (driver.Result, error) {
+					query = q
+					return driver.RowsAffected(1), nil
+				},
+			}
+			c := NewCrud(openFake(t, db), "")
+
+			if err := c.CreateStructContext(context.Background(), "T", tt.model); err != nil {
+				t.Fatal(err)
+			}
+			if strings.TrimSpace(query) != tt.want {
+				t.Fatalf("query = %q, esperado %q", query, tt.want)
+			}
+		})
+	}
+}
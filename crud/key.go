@@ -0,0 +1,111 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// positional devolve um gerador de placeholders posicionais (:1, :2, ...).
+func positional() func() string {
+	n := 0
+	return func() string {
+		n++
+		return fmt.Sprintf(":%d", n)
+	}
+}
+
+// pkWhere gera a condição de igualdade sobre todas as colunas da pk, com
+// placeholders obtidos de next.
+func (m *modelMeta) pkWhere(next func() string) string {
+	conds := make([]string, 0, len(m.Keys))
+	for _, f := range m.Keys {
+		conds = append(conds, fmt.Sprintf("%s = %s", f.Column, next()))
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// pkValues devolve os valores da pk de v, na ordem de Keys.
+func (m *modelMeta) pkValues(v reflect.Value) []any {
+	values := make([]any, 0, len(m.Keys))
+	for _, f := range m.Keys {
+		values = append(values, m.value(v, f))
+	}
+	return values
+}
+
+// pkValue identifica a linha em mensagens de erro: o valor da pk simples ou a
+// lista de valores da pk composta.
+func (m *modelMeta) pkValue(v reflect.Value) any {
+	if len(m.Keys) == 1 {
+		return m.value(v, m.Keys[0])
+	}
+	return m.pkValues(v)
+}
+
+// ApplyKey copia para model (ponteiro para struct) os valores da pk contidos
+// em key, uma struct cujos campos com tag db têm o nome das colunas da pk.
+// Para pk simples, key também pode ser o próprio valor.
+func ApplyKey(model, key any) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("model deve ser ponteiro para struct")
+	}
+	v = v.Elem()
+
+	meta, err := metaOf(v.Type())
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
+
+	kv := reflect.ValueOf(key)
+	for kv.Kind() == reflect.Ptr && !kv.IsNil() {
+		kv = kv.Elem()
+	}
+	if !kv.IsValid() || kv.Kind() == reflect.Ptr {
+		return fmt.Errorf("key não pode ser nil")
+	}
+	if kv.Kind() != reflect.Struct {
+		if len(meta.Keys) != 1 {
+			return fmt.Errorf("%w: pk de %s tem %d colunas; informe uma struct", ErrInvalidColumn, meta.Type, len(meta.Keys))
+		}
+		return setKey(v.FieldByIndex(meta.Keys[0].Index), kv, meta.Keys[0])
+	}
+
+	set := make(map[*field]bool, len(meta.Keys))
+	for i := 0; i < kv.NumField(); i++ {
+		tag := kv.Type().Field(i).Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name, _ := parseTag(tag)
+		f := meta.fieldByColumn(name)
+		if f == nil || !f.PK {
+			return fmt.Errorf("%w: %q não faz parte da pk de %s", ErrInvalidColumn, name, meta.Type)
+		}
+		if err := setKey(v.FieldByIndex(f.Index), kv.Field(i), f); err != nil {
+			return err
+		}
+		set[f] = true
+	}
+
+	for _, f := range meta.Keys {
+		if !set[f] {
+			return fmt.Errorf("%w: pk de %s sem valor para %s", ErrInvalidColumn, meta.Type, f.Column)
+		}
+	}
+
+	return nil
+}
+
+func setKey(dst, src reflect.Value, f *field) error {
+	if !src.Type().ConvertibleTo(dst.Type()) {
+		return fmt.Errorf("%w: %s não aceita valor do tipo %s", ErrInvalidColumn, f.Column, src.Type())
+	}
+	dst.Set(src.Convert(dst.Type()))
+	return nil
+}
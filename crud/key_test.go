@@ -0,0 +1,55 @@
+package crud
+
+import "testing"
+
+type orderItem struct {
+	OrderID int64  `db:"ORDER_ID,pk"`
+	Line    int    `db:"LINE,pk"`
+	Sku     string `db:"SKU"`
+}
+
+func TestApplyKey(t *testing.T) {
+	var item orderItem
+	key := struct {
+		OrderID int64 `db:"ORDER_ID"`
+		Line    int   `db:"LINE"`
+	}{OrderID: 7, Line: 2}
+
+	if err := ApplyKey(&item, key); err != nil {
+		t.Fatal(err)
+	}
+	if item.OrderID != 7 || item.Line != 2 {
+		t.Fatalf("item = %+v", item)
+	}
+	if err := ApplyKey(&item, &key); err != nil {
+		t.Fatalf("key ponteiro: %v", err)
+	}
+
+	var p benchProduct
+	if err := ApplyKey(&p, int64(42)); err != nil || p.ID != 42 {
+		t.Fatalf("pk simples: %+v, %v", p, err)
+	}
+}
+
+func TestApplyKeyRejectsInvalidKeys(t *testing.T) {
+	var nilID *int64
+	tests := map[string]struct {
+		model any
+		key   any
+	}{
+		"nil":                    {&benchProduct{}, nil},
+		"ponteiro nil":           {&benchProduct{}, nilID},
+		"escalar em pk composta": {&orderItem{}, int64(1)},
+		"coluna fora da pk": {&orderItem{}, struct {
+			Sku string `db:"SKU"`
+		}{"X"}},
+		"model que não é ponteiro": {orderItem{}, int64(1)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := ApplyKey(tt.model, tt.key); err == nil {
+				t.Fatal("esperado erro")
+			}
+		})
+	}
+}
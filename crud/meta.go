@@ -0,0 +1,467 @@
+package crud
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// field descreve um campo do model mapeado pela tag `db:"COLUNA,opcoes..."`.
+type field struct {
+	Name    string
+	Column  string
+	Index   []int
+	PK      bool
+	Seq     string
+	Options map[string]string
+}
+
+func (f *field) has(option string) bool {
+	_, ok := f.Options[option]
+	return ok
+}
+
+// modelMeta é o schema de um tipo de model, calculado uma única vez por tipo.
+type modelMeta struct {
+	Type       reflect.Type
+	Fields     []*field
+	Columns    []string
+	PK         *field   // primeira coluna da pk; a única que pode usar sequence
+	Keys       []*field // todas as colunas da pk, na ordem da struct
+	Version    *field
+	SoftDelete *field
+
+	byColumn   map[string]*field
+	statements sync.Map // tabela -> *statements
+}
+
+// statements guarda o SQL pré-montado de um model para uma tabela.
+type statements struct {
+	insert              string
+	insertBinds         []bind
+	insertOut           []*field // colunas devolvidas pelo RETURNING do insert
+	insertBulk          string
+	bulkBinds           []bind
+	update              string
+	updateBinds         []bind // binds do SET; seguidos da pk e da versão
+	updateOut           []*field
+	deleteByPK          string
+	deleteBinds         []bind // binds do SET; seguidos da pk e da versão
+	deleteByPKVersioned string
+	selectFrom          string
+	selectAll           string
+	selectByPK          string
+	selectByPKAll       string
+}
+
+var metaCache sync.Map // reflect.Type -> *modelMeta
+
+// parseTag interpreta a gramática da tag db: o primeiro item é a coluna e os
+// demais são opções no formato "nome" ou "nome=valor".
+func parseTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	options := make(map[string]string, len(parts)-1)
+
+	for _, p := range parts[1:] {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(p, "=")
+		options[name] = value
+	}
+
+	return strings.TrimSpace(parts[0]), options
+}
+
+func metaOf(t reflect.Type) (*modelMeta, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if cached, ok := metaCache.Load(t); ok {
+		return cached.(*modelMeta), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model deve ser struct, recebido %s", t)
+	}
+
+	m := &modelMeta{Type: t, byColumn: make(map[string]*field)}
+	if err := m.collect(t, nil, "", ""); err != nil {
+		return nil, err
+	}
+
+	if len(m.Fields) == 0 {
+		return nil, fmt.Errorf("model %s não possui campos com tag db", t)
+	}
+
+	actual, _ := metaCache.LoadOrStore(t, m)
+	return actual.(*modelMeta), nil
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// nested indica structs cujos campos viram colunas do model: embutidas sem
+// nome de coluna na tag ou com a opção `prefix=`. Tipos lidos como valor único
+// (time.Time, sql.Null*, Null[T]) nunca são expandidos.
+func nested(sf reflect.StructField, column string, options map[string]string) bool {
+	t := sf.Type
+	if t.Kind() != reflect.Struct || t == timeType || reflect.PointerTo(t).Implements(scannerType) {
+		return false
+	}
+	if _, ok := options["prefix"]; ok {
+		return true
+	}
+	return sf.Anonymous && column == ""
+}
+
+// collect percorre os campos de t, descendo em structs aninhadas; index e
+// prefix acumulam o caminho e o prefixo de coluna do nível atual.
+func (m *modelMeta) collect(t reflect.Type, index []int, prefix, path string) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+
+		name, options := parseTag(tag)
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldPath := path + sf.Name
+
+		// FieldByIndex não atravessa ponteiro nil; com ou sem tag, o erro
+		// evita que as colunas da struct sumam do model sem aviso
+		if sf.Anonymous && sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct {
+			return fmt.Errorf("%s.%s: struct embutida por ponteiro não é suportada", m.Type, fieldPath)
+		}
+
+		if nested(sf, name, options) {
+			if err := m.collect(sf.Type, fieldIndex, prefix+options["prefix"], fieldPath+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+
+		name = prefix + name
+		_, isPK := options["pk"]
+
+		// `quoted` preserva maiúsculas e minúsculas; Column guarda o
+		// identificador já pronto para o SQL
+		column, err := identifier(name)
+		if _, quoted := options["quoted"]; quoted {
+			column, err = quoteIdentifier(name)
+		}
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", m.Type, fieldPath, err)
+		}
+		if slices.Contains(m.Columns, column) {
+			return fmt.Errorf("%s.%s: coluna %s repetida", m.Type, fieldPath, column)
+		}
+
+		seq := options["seq"]
+		if seq != "" {
+			if seq, err = qualifiedIdentifier(seq); err != nil {
+				return fmt.Errorf("%s.%s: %w", m.Type, fieldPath, err)
+			}
+		}
+
+		f := &field{
+			Name:    fieldPath,
+			Column:  column,
+			Index:   fieldIndex,
+			PK:      isPK,
+			Seq:     seq,
+			Options: options,
+		}
+
+		if f.PK {
+			if m.PK == nil {
+				m.PK = f
+			}
+			m.Keys = append(m.Keys, f)
+		}
+		if f.has("version") && m.Version == nil {
+			m.Version = f
+		}
+		if f.has("softdelete") && m.SoftDelete == nil {
+			m.SoftDelete = f
+		}
+
+		m.Fields = append(m.Fields, f)
+		m.Columns = append(m.Columns, column)
+		if column != name {
+			m.byColumn[name] = f
+			m.byColumn[column] = f
+		} else {
+			m.byColumn[strings.ToUpper(column)] = f
+		}
+	}
+
+	return nil
+}
+
+// modelValue resolve o valor da struct apontada por model e o seu schema.
+func modelValue(model any) (reflect.Value, *modelMeta, error) {
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, nil, fmt.Errorf("model nil")
+		}
+		v = v.Elem()
+	}
+
+	m, err := metaOf(v.Type())
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	return v, m, nil
+}
+
+func (m *modelMeta) requirePK() error {
+	if m.PK == nil {
+		return fmt.Errorf("pk não encontrada no model")
+	}
+	return nil
+}
+
+// fieldByColumn busca o campo pelo nome da coluna, sem diferenciar maiúsculas
+// exceto em colunas `quoted`, que só casam pelo nome exato.
+func (m *modelMeta) fieldByColumn(column string) *field {
+	if f, ok := m.byColumn[column]; ok {
+		return f
+	}
+	return m.byColumn[strings.ToUpper(column)]
+}
+
+func (m *modelMeta) value(v reflect.Value, f *field) any {
+	return v.FieldByIndex(f.Index).Interface()
+}
+
+// scanTargets preenche dst com ponteiros para os campos de v, na ordem de Columns.
+func (m *modelMeta) scanTargets(v reflect.Value, dst []any) []any {
+	dst = dst[:0]
+	for _, f := range m.Fields {
+		dst = append(dst, v.FieldByIndex(f.Index).Addr().Interface())
+	}
+	return dst
+}
+
+// managed indica colunas mantidas pelo próprio crud ou pelo banco (versão,
+// exclusão lógica, auditoria e colunas geradas), que nunca recebem valores do
+// model em INSERT ou UPDATE.
+func (m *modelMeta) managed(f *field) bool {
+	return f == m.Version || f == m.SoftDelete || f.audit() || f.generated()
+}
+
+// generated indica colunas preenchidas pelo banco no INSERT: `identity` para
+// colunas GENERATED AS IDENTITY e `generated` para trigger ou DEFAULT.
+func (f *field) generated() bool {
+	return f.has("identity") || f.has("generated")
+}
+
+// filledByDB indica a pk simples sem sequence: sem a opção `assigned`, ela é
+// preenchida pelo banco (trigger ou DEFAULT) e devolvida pelo RETURNING. Em pk
+// composta o valor sempre vem do model.
+func (m *modelMeta) filledByDB(f *field) bool {
+	return f.PK && f.Seq == "" && len(m.Keys) == 1 && !f.has("assigned")
+}
+
+// returnedFields lista as colunas devolvidas ao model pelo RETURNING, na mesma
+// ida ao banco do INSERT (onInsert) ou do UPDATE: pk gerada, colunas geradas
+// e auditoria.
+func (m *modelMeta) returnedFields(onInsert bool) []*field {
+	var fields []*field
+	for _, f := range m.Fields {
+		if f.PK && !onInsert {
+			continue
+		}
+		if f.generated() || f.audit() || (f.PK && f.Seq != "") || m.filledByDB(f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// deleteSQL gera o DELETE pela pk; com `softdelete` vira um UPDATE que marca a
+// linha como excluída, preservando-a para auditoria. Os binds retornados
+// precedem a pk.
+func (m *modelMeta) deleteSQL(table string) (string, []bind) {
+	next := positional()
+
+	if m.SoftDelete == nil {
+		return fmt.Sprintf("DELETE FROM %s WHERE %s", table, m.pkWhere(next)), nil
+	}
+
+	sets, binds := m.touchSets(next)
+	sets = append([]string{m.SoftDelete.Column + " = SYSTIMESTAMP"}, sets...)
+
+	return fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s AND %s IS NULL",
+		table,
+		strings.Join(sets, ", "),
+		m.pkWhere(next),
+		m.SoftDelete.Column,
+	), binds
+}
+
+// notDeleted é o filtro que exclui as linhas excluídas logicamente, vazio
+// para models sem `softdelete`.
+func (m *modelMeta) notDeleted() string {
+	if m.SoftDelete == nil {
+		return ""
+	}
+	return fmt.Sprintf(" AND %s IS NULL", m.SoftDelete.Column)
+}
+
+// returning gera a cláusula RETURNING ... INTO para columns, com placeholders
+// a partir de next.
+func returning(fields []*field, next func() string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	columns := make([]string, 0, len(fields))
+	into := make([]string, 0, len(fields))
+	for _, f := range fields {
+		columns = append(columns, f.Column)
+		into = append(into, next())
+	}
+
+	return fmt.Sprintf(" RETURNING %s INTO %s", strings.Join(columns, ", "), strings.Join(into, ", "))
+}
+
+func (m *modelMeta) sql(table string) *statements {
+	if cached, ok := m.statements.Load(table); ok {
+		return cached.(*statements)
+	}
+
+	s := &statements{}
+
+	argIndex := 0
+	next := func() string {
+		argIndex++
+		return fmt.Sprintf(":%d", argIndex)
+	}
+
+	// insertValues decide o valor de cada coluna no INSERT; bulk indica o
+	// insert em lote, em que a pk com sequence é pré-alocada e enviada como bind
+	insertValues := func(bulk bool) (columns, values []string, binds []bind) {
+		for _, f := range m.Fields {
+			switch {
+			case f.generated():
+				// identity, trigger ou default: o banco preenche e o RETURNING devolve
+				continue
+			case f.PK && f.Seq != "" && !bulk:
+				columns = append(columns, f.Column)
+				values = append(values, qualifySequence(table, f.Seq)+".NEXTVAL")
+			case m.filledByDB(f) && !bulk:
+				// o insert em lote não tem RETURNING e envia a pk do model
+				continue
+			case f == m.SoftDelete:
+				// linha nova nunca nasce excluída
+				continue
+			case f == m.Version:
+				// toda linha nasce na versão 1
+				columns = append(columns, f.Column)
+				values = append(values, "1")
+			case f.timestampAudit():
+				columns = append(columns, f.Column)
+				values = append(values, "SYSTIMESTAMP")
+			case f.principalAudit():
+				columns = append(columns, f.Column)
+				values = append(values, principalExpr(next()))
+				binds = append(binds, bind{principal: true})
+			default:
+				columns = append(columns, f.Column)
+				values = append(values, next())
+				binds = append(binds, bind{field: f})
+			}
+		}
+		return columns, values, binds
+	}
+
+	bulkColumns, bulkValues, bulkBinds := insertValues(true)
+	s.bulkBinds = bulkBinds
+	s.insertBulk = fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		table,
+		strings.Join(bulkColumns, ", "),
+		strings.Join(bulkValues, ", "),
+	)
+
+	argIndex = 0
+	columns, values, insertBinds := insertValues(false)
+	s.insertBinds = insertBinds
+	s.insertOut = m.returnedFields(true)
+
+	insert := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		table,
+		strings.Join(columns, ", "),
+		strings.Join(values, ", "),
+	)
+	s.insert = insert + returning(s.insertOut, next)
+
+	s.selectFrom = fmt.Sprintf("SELECT %s FROM %s", strings.Join(m.Columns, ", "), table)
+
+	// leituras padrão ignoram linhas excluídas logicamente
+	if m.SoftDelete != nil {
+		s.selectAll = fmt.Sprintf("%s WHERE %s IS NULL ORDER BY %s", s.selectFrom, m.SoftDelete.Column, m.Columns[0])
+	} else {
+		s.selectAll = fmt.Sprintf("%s ORDER BY %s", s.selectFrom, m.Columns[0])
+	}
+
+	if m.PK != nil {
+		argIndex = 0
+		var sets []string
+		for _, f := range m.Fields {
+			if f.PK || m.managed(f) {
+				continue
+			}
+			sets = append(sets, fmt.Sprintf("%s = %s", f.Column, next()))
+			s.updateBinds = append(s.updateBinds, bind{field: f})
+		}
+
+		touch, touchBinds := m.touchSets(next)
+		sets = append(sets, touch...)
+		s.updateBinds = append(s.updateBinds, touchBinds...)
+
+		// linhas excluídas logicamente só voltam por Restore; sem colunas
+		// além da pk, s.update fica vazio e UpdateStruct falha
+		s.update = fmt.Sprintf(
+			"UPDATE %s SET %s WHERE %s%s",
+			table,
+			strings.Join(sets, ", "),
+			m.pkWhere(next),
+			m.notDeleted(),
+		)
+		s.deleteByPK, s.deleteBinds = m.deleteSQL(table)
+		s.deleteByPKVersioned = s.deleteByPK
+
+		// com versão, UPDATE e DELETE só afetam a linha na versão lida
+		if m.Version != nil {
+			s.update += fmt.Sprintf(" AND %s = %s", m.Version.Column, next())
+			s.deleteByPKVersioned += fmt.Sprintf(" AND %s = :%d", m.Version.Column, len(s.deleteBinds)+len(m.Keys)+1)
+		}
+		s.updateOut = m.returnedFields(false)
+		s.update += returning(s.updateOut, next)
+		if len(sets) == 0 {
+			s.update = ""
+		}
+
+		argIndex = 0
+		s.selectByPKAll = fmt.Sprintf("%s WHERE %s", s.selectFrom, m.pkWhere(next))
+		s.selectByPK = s.selectByPKAll + m.notDeleted()
+	}
+
+	actual, _ := m.statements.LoadOrStore(table, s)
+	return actual.(*statements)
+}
@@ -0,0 +1,75 @@
+package crud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type metaAudit struct {
+	CreatedAt *time.Time `db:"CREATED_AT,autocreate"`
+	CreatedBy *string    `db:"CREATED_BY,createdby"`
+}
+
+type metaAddress struct {
+	Street string `db:"STREET"`
+	City   string `db:"CITY"`
+}
+
+type metaCustomer struct {
+	ID       int64       `db:"ID,pk"`
+	Name     string      `db:"NAME"`
+	Billing  metaAddress `db:",prefix=BILLING_"`
+	Shipping metaAddress `db:",prefix=SHIP_"`
+	Since    time.Time   `db:"SINCE"`
+	metaAudit
+}
+
+func TestCollectNestedColumns(t *testing.T) {
+	meta, err := metaOf(reflect.TypeOf(metaCustomer{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"ID", "NAME",
+		"BILLING_STREET", "BILLING_CITY",
+		"SHIP_STREET", "SHIP_CITY",
+		"SINCE",
+		"CREATED_AT", "CREATED_BY",
+	}
+	if !reflect.DeepEqual(meta.Columns, want) {
+		t.Fatalf("colunas = %v, esperado %v", meta.Columns, want)
+	}
+
+	// o índice de cada coluna precisa levar ao campo aninhado certo
+	c := metaCustomer{ID: 7}
+	c.Shipping.City = "Recife"
+	v := reflect.ValueOf(c)
+	if got := meta.value(v, meta.fieldByColumn("SHIP_CITY")); got != "Recife" {
+		t.Fatalf("SHIP_CITY = %v", got)
+	}
+	if f := meta.fieldByColumn("CREATED_BY"); f == nil || f.Name != "metaAudit.CreatedBy" || !f.principalAudit() {
+		t.Fatalf("CREATED_BY mapeada como %+v", f)
+	}
+}
+
+type metaEmbeddedPtr struct {
+	ID int64 `db:"ID,pk"`
+	*metaAudit
+}
+
+type metaEmbeddedPtrTagged struct {
+	ID         int64 `db:"ID,pk"`
+	*metaAudit `db:",prefix=X_"`
+}
+
+func TestCollectRejectsEmbeddedPointer(t *testing.T) {
+	for _, model := range []any{metaEmbeddedPtr{}, metaEmbeddedPtrTagged{}} {
+		_, err := metaOf(reflect.TypeOf(model))
+		if err == nil || !strings.Contains(err.Error(), "ponteiro") {
+			t.Fatalf("%T: err = %v, esperado erro de struct embutida por ponteiro", model, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package crud
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Null representa uma coluna anulável de qualquer tipo, lida e gravada como
+// NULL quando Valid é false. Em JSON é serializada como o valor ou null.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// NullOf cria um Null válido com v.
+func NullOf[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+func (n *Null[T]) Scan(value any) error {
+	var s sql.Null[T]
+	if err := s.Scan(value); err != nil {
+		return err
+	}
+	n.V, n.Valid = s.V, s.Valid
+	return nil
+}
+
+func (n Null[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: n.V, Valid: n.Valid}.Value()
+}
+
+// Ptr devolve um ponteiro para o valor, ou nil quando NULL.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// bindValue converte o campo f de v no valor enviado ao banco: ponteiros são
+// desreferenciados (nil vira NULL) e `omitempty` grava NULL para o valor zero.
+func (f *field) bindValue(v reflect.Value) any {
+	fv := v.FieldByIndex(f.Index)
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	if f.has("omitempty") && fv.IsZero() {
+		return nil
+	}
+
+	return fv.Interface()
+}
+
+// bulkColumn monta o array de um campo para o insert em lote. Campos anuláveis
+// (ponteiro ou `omitempty`) viram arrays sql.Null*, já que arrays de tipos
+// simples não representam NULL.
+func bulkColumn(rows reflect.Value, start, end int, f *field) any {
+	t := rows.Type().Elem().FieldByIndex(f.Index).Type
+	nullable := t.Kind() == reflect.Ptr || f.has("omitempty")
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if nullable {
+		if column := nullColumn(rows, start, end, f, t); column != nil {
+			return column
+		}
+	}
+
+	column := reflect.MakeSlice(reflect.SliceOf(t), end-start, end-start)
+	for row := start; row < end; row++ {
+		if fv := rows.Index(row).FieldByIndex(f.Index); fv.Kind() != reflect.Ptr {
+			column.Index(row - start).Set(fv)
+		} else if !fv.IsNil() {
+			column.Index(row - start).Set(fv.Elem())
+		}
+	}
+	return column.Interface()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func nullColumn(rows reflect.Value, start, end int, f *field, t reflect.Type) any {
+	valid := func(row int) (reflect.Value, bool) {
+		fv := rows.Index(row).FieldByIndex(f.Index)
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				return fv, false
+			}
+			fv = fv.Elem()
+		}
+		return fv, !(f.has("omitempty") && fv.IsZero())
+	}
+
+	switch {
+	case t == timeType:
+		column := make([]sql.NullTime, end-start)
+		for row := start; row < end; row++ {
+			if fv, ok := valid(row); ok {
+				column[row-start] = sql.NullTime{Time: fv.Interface().(time.Time), Valid: true}
+			}
+		}
+		return column
+	case t.Kind() == reflect.String:
+		column := make([]sql.NullString, end-start)
+		for row := start; row < end; row++ {
+			if fv, ok := valid(row); ok {
+				column[row-start] = sql.NullString{String: fv.String(), Valid: true}
+			}
+		}
+		return column
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		column := make([]sql.NullInt64, end-start)
+		for row := start; row < end; row++ {
+			if fv, ok := valid(row); ok {
+				column[row-start] = sql.NullInt64{Int64: fv.Int(), Valid: true}
+			}
+		}
+		return column
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		column := make([]sql.NullFloat64, end-start)
+		for row := start; row < end; row++ {
+			if fv, ok := valid(row); ok {
+				column[row-start] = sql.NullFloat64{Float64: fv.Float(), Valid: true}
+			}
+		}
+		return column
+	case t.Kind() == reflect.Bool:
+		column := make([]sql.NullBool, end-start)
+		for row := start; row < end; row++ {
+			if fv, ok := valid(row); ok {
+				column[row-start] = sql.NullBool{Bool: fv.Bool(), Valid: true}
+			}
+		}
+		return column
+	}
+	return nil
+}
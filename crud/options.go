@@ -0,0 +1,39 @@
+package crud
+
+// QueryOption ajusta consultas de leitura (ListStruct, FindWhere, ...).
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	offset int
+	limit  int
+	orders []Order
+	sort   []Order
+
+	includeDeleted bool
+}
+
+// Order é um item de ORDER BY.
+type Order struct {
+	Column string
+	Desc   bool
+}
+
+func newQueryOptions(opts []QueryOption) queryOptions {
+	var o queryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Paginate limita o resultado com OFFSET ... FETCH NEXT ... ROWS ONLY (Oracle 12c+).
+// limit <= 0 desativa o limite.
+func Paginate(offset, limit int) QueryOption {
+	return func(o *queryOptions) {
+		if offset < 0 {
+			offset = 0
+		}
+		o.offset = offset
+		o.limit = limit
+	}
+}
@@ -0,0 +1,133 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FindWhere carrega em dest (ponteiro para slice de struct) as linhas que
+// satisfazem cond. Uma condição nil retorna a tabela inteira.
+func (c *Crud) FindWhere(ctx context.Context, table string, dest any, cond Condition, opts ...QueryOption) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	sliceValue, meta, err := sliceDest(dest)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := buildSelect(meta, table, cond, newQueryOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanRows(rows, meta, sliceValue)
+}
+
+// FindOne carrega em dest (ponteiro para struct) a primeira linha que satisfaz
+// cond, retornando ErrNotFound quando não há nenhuma.
+func (c *Crud) FindOne(ctx context.Context, table string, dest any, cond Condition, opts ...QueryOption) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest deve ser ponteiro para struct")
+	}
+
+	elem := v.Elem()
+	meta, err := metaOf(elem.Type())
+	if err != nil {
+		return err
+	}
+
+	o := newQueryOptions(opts)
+	o.limit = 1
+
+	query, args, err := buildSelect(meta, table, cond, o)
+	if err != nil {
+		return err
+	}
+
+	return c.queryRow(ctx, query, args, meta.scanTargets(elem, make([]any, 0, len(meta.Fields)))...)
+}
+
+// Count retorna o número de linhas de table que satisfazem cond. model é usado
+// apenas para validar as colunas da condição.
+func (c *Crud) Count(ctx context.Context, table string, model any, cond Condition, opts ...QueryOption) (int64, error) {
+	table, err := c.table(table)
+	if err != nil {
+		return 0, err
+	}
+
+	meta, err := metaOf(reflect.TypeOf(model))
+	if err != nil {
+		return 0, err
+	}
+
+	whereSQL, args, err := where(meta, meta.scope(cond, newQueryOptions(opts)), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s%s", table, whereSQL)
+	err = c.queryRow(ctx, query, args, &total)
+	return total, err
+}
+
+func buildSelect(meta *modelMeta, table string, cond Condition, o queryOptions) (string, []any, error) {
+	whereSQL, args, err := where(meta, meta.scope(cond, o), nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(meta.sql(table).selectFrom)
+	sb.WriteString(whereSQL)
+	orders := o.orders
+	if len(o.sort) > 0 {
+		if orders, err = meta.sortOrders(o.sort); err != nil {
+			return "", nil, err
+		}
+	}
+
+	sb.WriteString(" ORDER BY ")
+	if len(orders) == 0 {
+		sb.WriteString(meta.Columns[0])
+	}
+	for i, order := range orders {
+		f := meta.fieldByColumn(order.Column)
+		if f == nil {
+			return "", nil, fmt.Errorf("%w: %q não mapeada em %s", ErrInvalidColumn, order.Column, meta.Type)
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(f.Column)
+		if order.Desc {
+			sb.WriteString(" DESC")
+		}
+	}
+
+	if o.offset > 0 {
+		fmt.Fprintf(&sb, " OFFSET %d ROWS", o.offset)
+	}
+	if o.limit > 0 {
+		fmt.Fprintf(&sb, " FETCH NEXT %d ROWS ONLY", o.limit)
+	}
+
+	return sb.String(), args, nil
+}
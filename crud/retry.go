@@ -0,0 +1,179 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RetryPolicy repete operações que falharam por indisponibilidade transitória
+// do banco (queda de conexão, reinício do listener). Só são repetidas leituras
+// e escritas marcadas com Idempotent, e nunca dentro de transação: a falha de
+// conexão desfaz a transação inteira.
+type RetryPolicy struct {
+	// MaxAttempts conta a primeira tentativa; 1 ou menos desativa a repetição.
+	MaxAttempts int
+	// BaseDelay é a espera antes da segunda tentativa, dobrada a cada nova
+	// tentativa até MaxDelay; metade da espera é sorteada (jitter).
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Budget limita as repetições em relação aos sucessos; nil não limita.
+	Budget *RetryBudget
+	// OnRetry é chamado antes de cada espera, com a tentativa que falhou.
+	OnRetry func(ctx context.Context, attempt int, delay time.Duration, err error)
+}
+
+// retryable diz se err, já traduzido, é uma falha transitória: as falhas de
+// sessão, de listener e de rede que translate classifica como ErrConnection.
+func retryable(err error) bool {
+	var dbErr *DBError
+	return errors.As(err, &dbErr) && dbErr.Kind == ErrConnection
+}
+
+// WithRetry retorna um Crud que aplica policy às operações. Em um Crud ligado
+// a uma transação não há repetição e c é devolvido sem alteração.
+func (c *Crud) WithRetry(policy RetryPolicy) *Crud {
+	if c.tx != nil {
+		return c
+	}
+
+	retrying := *c
+	retrying.retry = &policy
+	retrying.conn = retryingExecutor{executor: translatingExecutor{c.db}, policy: &policy}
+	return &retrying
+}
+
+type idempotentKey struct{}
+
+// Idempotent marca as escritas feitas com ctx como seguras para repetir: o
+// efeito de executá-las duas vezes é o mesmo de executá-las uma vez.
+func Idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context) bool {
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+// do executa fn até que tenha sucesso, falhe de forma não transitória ou
+// esgote as tentativas, o orçamento ou o contexto.
+func (p *RetryPolicy) do(ctx context.Context, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			p.Budget.deposit()
+			return nil
+		}
+		if attempt >= p.MaxAttempts || !retryable(err) || ctx.Err() != nil || !p.Budget.withdraw() {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(ctx, attempt, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff calcula a espera após a tentativa attempt: BaseDelay * 2^(attempt-1),
+// limitada a MaxDelay, com a metade superior sorteada.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay <= 0) {
+		delay = p.MaxDelay
+	}
+	if delay <= 1 {
+		return delay
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half)
+}
+
+// RetryBudget impede que uma indisponibilidade do banco multiplique a carga
+// sobre ele: cada sucesso deposita ratio fichas (até max) e cada repetição
+// consome uma. Pode ser compartilhado entre políticas.
+type RetryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+	max    float64
+}
+
+// NewRetryBudget cria um orçamento cheio que permite, em regime, repetir
+// ratio operações por sucesso e até max repetições seguidas.
+func NewRetryBudget(ratio float64, max int) *RetryBudget {
+	return &RetryBudget{tokens: float64(max), ratio: ratio, max: float64(max)}
+}
+
+func (b *RetryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *RetryBudget) deposit() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, b.max)
+}
+
+// retryingExecutor repete consultas e, quando o contexto é Idempotent, as
+// demais instruções. Consultas falham antes de entregar linhas, então
+// repeti-las é sempre seguro.
+type retryingExecutor struct {
+	executor
+	policy *RetryPolicy
+}
+
+func (e retryingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if !isIdempotent(ctx) {
+		return e.executor.ExecContext(ctx, query, args...)
+	}
+
+	var result sql.Result
+	err := e.policy.do(ctx, func() (err error) {
+		result, err = e.executor.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (e retryingExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := e.policy.do(ctx, func() (err error) {
+		rows, err = e.executor.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
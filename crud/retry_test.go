@@ -0,0 +1,144 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{70, 500 * time.Millisecond, time.Second}, // overflow do deslocamento
+	}
+	for _, tt := range tests {
+		for range 100 {
+			if d := p.backoff(tt.attempt); d < tt.min || d >= tt.max {
+				t.Fatalf("backoff(%d) = %v, esperado em [%v, %v)", tt.attempt, d, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	b := NewRetryBudget(0.5, 2)
+
+	if !b.withdraw() || !b.withdraw() {
+		t.Fatal("orçamento cheio deve permitir 2 repetições")
+	}
+	if b.withdraw() {
+		t.Fatal("orçamento esgotado permitiu repetição")
+	}
+
+	b.deposit()
+	if b.withdraw() {
+		t.Fatal("meia ficha permitiu repetição")
+	}
+	b.deposit()
+	b.deposit()
+	if !b.withdraw() {
+		t.Fatal("uma ficha deve permitir repetição")
+	}
+
+	for range 10 {
+		b.deposit()
+	}
+	if !b.withdraw() || !b.withdraw() || b.withdraw() {
+		t.Fatal("depósitos devem respeitar o máximo")
+	}
+
+	var unlimited *RetryBudget
+	if !unlimited.withdraw() {
+		t.Fatal("orçamento nil não limita")
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := translate(network.NewOracleError(12519))
+	permanent := translate(network.NewOracleError(1))
+
+	tests := []struct {
+		name      string
+		err       error
+		budget    *RetryBudget
+		wantCalls int
+	}{
+		{"transitória", transient, nil, 3},
+		{"não transitória", permanent, nil, 1},
+		{"erro sem classificação", errors.New("x"), nil, 1},
+		{"orçamento esgotado", transient, NewRetryBudget(0, 1), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var retries int
+			p := &RetryPolicy{
+				MaxAttempts: 3,
+				BaseDelay:   time.Microsecond,
+				Budget:      tt.budget,
+				OnRetry:     func(context.Context, int, time.Duration, error) { retries++ },
+			}
+
+			calls := 0
+			err := p.do(context.Background(), func() error {
+				calls++
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) || calls != tt.wantCalls || retries != calls-1 {
+				t.Fatalf("err = %v, chamadas = %d, repetições = %d; esperado %d chamadas", err, calls, retries, tt.wantCalls)
+			}
+		})
+	}
+
+	calls := 0
+	p := &RetryPolicy{MaxAttempts: 3}
+	if err := p.do(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return transient
+		}
+		return nil
+	}); err != nil || calls != 2 {
+		t.Fatalf("sucesso na segunda tentativa: err = %v, chamadas = %d", err, calls)
+	}
+}
+
+func TestWithRetryIgnoresTransactions(t *testing.T) {
+	c := NewCrud(openFake(t, &fakeDB{}), "")
+
+	err := c.RunInTx(context.Background(), nil, func(tx *Crud) error {
+		if got := tx.WithRetry(RetryPolicy{MaxAttempts: 3}); got != tx || got.retry != nil {
+			t.Error("WithRetry em transação deve devolver o próprio Crud")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryableFollowsConnectionKind(t *testing.T) {
+	for code, kind := range oracleKinds {
+		err := translate(network.NewOracleError(code))
+		if got := retryable(err); got != (kind == ErrConnection) {
+			t.Fatalf("ORA-%05d (%v): retryable = %v", code, kind, got)
+		}
+	}
+	if !retryable(translate(driver.ErrBadConn)) {
+		t.Fatal("falha de conexão sem código ORA não repetida")
+	}
+	if retryable(translate(context.DeadlineExceeded)) {
+		t.Fatal("timeout repetido")
+	}
+}
@@ -0,0 +1,42 @@
+package crud
+
+// InSchema retorna um Crud que qualifica tabelas e sequences com schema,
+// mantendo a conexão e a transação atuais. Útil em implantações com vários
+// schemas na mesma base; "" usa o nome da tabela sem qualificação.
+func (c *Crud) InSchema(schema string) *Crud {
+	scoped := *c
+	scoped.schema = schema
+	return &scoped
+}
+
+// Schema retorna o schema usado para qualificar as tabelas.
+func (c *Crud) Schema() string {
+	return c.schema
+}
+
+// table valida table e a qualifica com o schema do Crud; nomes já
+// qualificados (SCHEMA.TABELA) são mantidos.
+func (c *Crud) table(table string) (string, error) {
+	table, err := qualifiedIdentifier(table)
+	if err != nil {
+		return "", err
+	}
+	if c.schema == "" || len(splitQualified(table)) > 1 {
+		return table, nil
+	}
+
+	schema, err := identifier(c.schema)
+	if err != nil {
+		return "", err
+	}
+	return schema + "." + table, nil
+}
+
+// qualifySequence aplica a seq o schema de uma tabela já qualificada.
+func qualifySequence(table, seq string) string {
+	tableParts := splitQualified(table)
+	if len(tableParts) == 1 || len(splitQualified(seq)) > 1 {
+		return seq
+	}
+	return tableParts[0] + "." + seq
+}
@@ -0,0 +1,247 @@
+package crud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SeekResult traz os cursores das páginas vizinhas; nil quando não há página.
+type SeekResult struct {
+	Next *Cursor
+	Prev *Cursor
+}
+
+// FindSeek pagina dest por keyset (seek): em vez de OFFSET, filtra pelas
+// linhas posteriores (ou anteriores) ao cursor, o que mantém o custo constante
+// e a navegação estável mesmo com inserções concorrentes. keys define a tupla
+// de ordenação; a pk é acrescentada quando ausente para garantir unicidade.
+// As colunas de keys não devem aceitar NULL.
+func (c *Crud) FindSeek(ctx context.Context, table string, dest any, cond Condition, cursor Cursor, limit int, keys []string, opts ...QueryOption) (SeekResult, error) {
+	table, err := c.table(table)
+	if err != nil {
+		return SeekResult{}, err
+	}
+
+	var result SeekResult
+
+	if limit <= 0 {
+		return result, fmt.Errorf("limit deve ser maior que zero")
+	}
+
+	sliceValue, meta, err := sliceDest(dest)
+	if err != nil {
+		return result, err
+	}
+
+	orders, err := meta.seekOrders(keys)
+	if err != nil {
+		return result, err
+	}
+
+	filter, err := seekFilter(meta, cond, newQueryOptions(opts))
+	if err != nil {
+		return result, err
+	}
+	order := FormatSort(orders)
+
+	if !cursor.IsZero() {
+		if !slices.Equal(cursor.Order, order) || cursor.Filter != filter {
+			return result, ErrInvalidCursor
+		}
+		values, err := meta.seekValues(orders, cursor.Keys)
+		if err != nil {
+			return result, err
+		}
+		cond = And(cond, seekCondition{orders: orders, values: values, backward: cursor.Backward})
+	}
+
+	queryOrders := orders
+	if cursor.Backward {
+		queryOrders = make([]Order, len(orders))
+		for i, o := range orders {
+			queryOrders[i] = Order{Column: o.Column, Desc: !o.Desc}
+		}
+	}
+
+	// uma linha extra indica se existe outra página na direção navegada
+	o := newQueryOptions(opts)
+	o.offset, o.sort = 0, nil
+	o.limit = limit + 1
+	o.orders = queryOrders
+
+	query, args, err := buildSelect(meta, table, cond, o)
+	if err != nil {
+		return result, err
+	}
+
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	sliceValue.SetLen(0)
+	if err := scanRows(rows, meta, sliceValue); err != nil {
+		return result, err
+	}
+
+	hasMore := sliceValue.Len() > limit
+	if hasMore {
+		sliceValue.SetLen(limit)
+	}
+	if cursor.Backward {
+		reverse(sliceValue)
+	}
+
+	n := sliceValue.Len()
+	if n == 0 {
+		// página vazia: permite voltar a partir do próprio cursor
+		if !cursor.IsZero() {
+			back := Cursor{Keys: cursor.Keys, Backward: !cursor.Backward, Order: order, Filter: filter}
+			if cursor.Backward {
+				result.Next = &back
+			} else {
+				result.Prev = &back
+			}
+		}
+		return result, nil
+	}
+
+	first := meta.seekKeys(sliceValue.Index(0), orders)
+	last := meta.seekKeys(sliceValue.Index(n-1), orders)
+
+	next := &Cursor{Keys: last, Order: order, Filter: filter}
+	prev := &Cursor{Keys: first, Backward: true, Order: order, Filter: filter}
+
+	if cursor.Backward {
+		result.Next = next
+		if hasMore {
+			result.Prev = prev
+		}
+	} else {
+		if hasMore {
+			result.Next = next
+		}
+		if !cursor.IsZero() {
+			result.Prev = prev
+		}
+	}
+
+	return result, nil
+}
+
+// seekFilter resume a condição efetiva da consulta (com os filtros implícitos
+// do model) para vincular a ela os cursores gerados.
+func seekFilter(meta *modelMeta, cond Condition, o queryOptions) (string, error) {
+	whereSQL, args, err := where(meta, meta.scope(cond, o), nil)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s%#v", whereSQL, args))
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+func (m *modelMeta) seekOrders(keys []string) ([]Order, error) {
+	if err := m.requirePK(); err != nil {
+		return nil, err
+	}
+
+	sort := make([]Order, len(keys))
+	for i, key := range keys {
+		o, ok := parseOrder(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: item vazio", ErrInvalidSort)
+		}
+		sort[i] = o
+	}
+
+	return m.sortOrders(sort)
+}
+
+// seekValues converte os valores do cursor (que podem vir de JSON) para o
+// tipo Go de cada coluna de ordenação.
+func (m *modelMeta) seekValues(orders []Order, keys []any) ([]any, error) {
+	if len(keys) != len(orders) {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		t := m.Type.FieldByIndex(m.fieldByColumn(orders[i].Column).Index).Type
+		if key != nil && reflect.TypeOf(key) == t {
+			values[i] = key
+			continue
+		}
+
+		raw, err := json.Marshal(key)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		ptr := reflect.New(t)
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+			return nil, ErrInvalidCursor
+		}
+		values[i] = ptr.Elem().Interface()
+	}
+
+	return values, nil
+}
+
+func (m *modelMeta) seekKeys(v reflect.Value, orders []Order) []any {
+	keys := make([]any, len(orders))
+	for i, o := range orders {
+		keys[i] = m.value(v, m.fieldByColumn(o.Column))
+	}
+	return keys
+}
+
+// seekCondition expande a comparação de tuplas, que o Oracle não suporta com
+// < e >: (a, b) > (:1, :2) vira a > :1 OR (a = :1 AND b > :2).
+type seekCondition struct {
+	orders   []Order
+	values   []any
+	backward bool
+}
+
+func (s seekCondition) render(b *condBuilder) (string, error) {
+	columns := make([]string, len(s.orders))
+	for i, o := range s.orders {
+		column, err := b.column(o.Column)
+		if err != nil {
+			return "", err
+		}
+		columns[i] = column
+	}
+
+	alternatives := make([]string, 0, len(s.orders))
+	for i, o := range s.orders {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s = %s", columns[j], b.bind(s.values[j])))
+		}
+
+		op := ">"
+		if o.Desc != s.backward {
+			op = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s", columns[i], op, b.bind(s.values[i])))
+
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", nil
+}
+
+func reverse(slice reflect.Value) {
+	swap := reflect.Swapper(slice.Interface())
+	for i, j := 0, slice.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
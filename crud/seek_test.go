@@ -0,0 +1,114 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type seekProduct struct {
+	ID    int64   `db:"ID,pk"`
+	Name  string  `db:"NAME,sortable"`
+	Price float64 `db:"PRICE,sortable"`
+}
+
+func TestSeekConditionRender(t *testing.T) {
+	meta, err := metaOf(reflect.TypeFor[seekProduct]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orders := []Order{{Column: "PRICE", Desc: true}, {Column: "ID"}}
+	tests := []struct {
+		name     string
+		backward bool
+		want     string
+	}{
+		{"forward", false, " WHERE ((PRICE < :1) OR (PRICE = :2 AND ID > :3))"},
+		{"backward", true, " WHERE ((PRICE > :1) OR (PRICE = :2 AND ID < :3))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := where(meta, seekCondition{orders: orders, values: []any{9.5, int64(3)}, backward: tt.backward}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("SQL = %q, esperado %q", got, tt.want)
+			}
+			if want := []any{9.5, 9.5, int64(3)}; !reflect.DeepEqual(args, want) {
+				t.Fatalf("args = %v, esperado %v", args, want)
+			}
+		})
+	}
+}
+
+func TestFindSeekRejectsCursorFromAnotherQuery(t *testing.T) {
+	db := &fakeDB{
+		query: func(string, []driver.NamedValue) (driver.Rows, error) {
+			return &fakeRows{
+				columns: []string{"ID", "NAME", "PRICE"},
+				values:  [][]driver.Value{{int64(1), "A", 1.0}, {int64(2), "B", 2.0}, {int64(3), "C", 3.0}},
+			}, nil
+		},
+	}
+	c := NewCrud(openFake(t, db), "")
+	ctx := context.Background()
+
+	var page []seekProduct
+	result, err := c.FindSeek(ctx, "PRODUCTS", &page, Gt("PRICE", 0.5), Cursor{}, 2, []string{"-PRICE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Next == nil {
+		t.Fatal("esperado cursor para a próxima página")
+	}
+	if want := []string{"-PRICE", "ID"}; !reflect.DeepEqual(result.Next.Order, want) {
+		t.Fatalf("Order = %v, esperado %v", result.Next.Order, want)
+	}
+
+	if _, err := c.FindSeek(ctx, "PRODUCTS", &page, Gt("PRICE", 0.5), *result.Next, 2, []string{"-PRICE"}); err != nil {
+		t.Fatalf("mesma consulta: %v", err)
+	}
+	if _, err := c.FindSeek(ctx, "PRODUCTS", &page, Gt("PRICE", 0.5), *result.Next, 2, []string{"NAME"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("outra ordenação: %v, esperado ErrInvalidCursor", err)
+	}
+	if _, err := c.FindSeek(ctx, "PRODUCTS", &page, Gt("PRICE", 1.5), *result.Next, 2, []string{"-PRICE"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("outro filtro: %v, esperado ErrInvalidCursor", err)
+	}
+}
+
+func TestSeekOrdersMatchSortValidation(t *testing.T) {
+	meta, err := metaOf(reflect.TypeFor[seekProduct]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		keys    []string
+		want    []Order
+		wantErr bool
+	}{
+		{keys: nil, want: []Order{{Column: "ID"}}},
+		{keys: []string{"-PRICE"}, want: []Order{{Column: "PRICE", Desc: true}, {Column: "ID"}}},
+		{keys: []string{"name", "-id"}, want: []Order{{Column: "NAME"}, {Column: "ID", Desc: true}}},
+		{keys: []string{"-PRICE", "PRICE"}, wantErr: true},
+		{keys: []string{"PRICE", "price"}, wantErr: true},
+		{keys: []string{"-"}, wantErr: true},
+		{keys: []string{"SECRET"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := meta.seekOrders(tt.keys)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidSort) {
+				t.Errorf("seekOrders(%v) = %v, %v; esperado ErrInvalidSort", tt.keys, got, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("seekOrders(%v) = %v, %v; esperado %v", tt.keys, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// IncludeDeleted inclui no resultado as linhas excluídas logicamente (`softdelete`).
+func IncludeDeleted() QueryOption {
+	return func(o *queryOptions) {
+		o.includeDeleted = true
+	}
+}
+
+// scope acrescenta a cond os filtros implícitos do model, como a exclusão lógica.
+func (m *modelMeta) scope(cond Condition, o queryOptions) Condition {
+	if m.SoftDelete == nil || o.includeDeleted {
+		return cond
+	}
+	return And(cond, IsNull(m.SoftDelete.Column))
+}
+
+// Restore desfaz a exclusão lógica da linha identificada pela pk de model.
+// Restaurar uma linha que não está excluída não tem efeito; ErrNotFound
+// indica que a linha não existe.
+func (c *Crud) Restore(ctx context.Context, table string, model any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
+	if meta.SoftDelete == nil {
+		return fmt.Errorf("model %s não possui coluna softdelete", meta.Type)
+	}
+
+	next := positional()
+	sets, binds := meta.touchSets(next)
+	sets = append([]string{meta.SoftDelete.Column + " = NULL"}, sets...)
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s AND %s IS NOT NULL",
+		table,
+		strings.Join(sets, ", "),
+		meta.pkWhere(next),
+		meta.SoftDelete.Column,
+	)
+
+	pk := meta.pkValues(v)
+
+	result, err := c.conn.ExecContext(ctx, query, append(bindArgs(ctx, meta, v, binds), pk...)...)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected > 0 {
+		return translate(err)
+	}
+
+	var exists int
+	query = fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table, meta.pkWhere(positional()))
+	if err := c.queryRow(ctx, query, pk, &exists); err != nil {
+		return err
+	}
+	if exists == 0 {
+		return notFound()
+	}
+
+	return nil
+}
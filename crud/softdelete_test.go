@@ -0,0 +1,94 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+type softProduct struct {
+	ID        int64      `db:"ID,pk"`
+	Name      string     `db:"NAME"`
+	Version   int64      `db:"VERSION,version"`
+	DeletedAt *time.Time `db:"DELETED_AT,softdelete"`
+}
+
+// softDB registra os comandos e responde como se a linha estivesse excluída
+// logicamente: nenhum UPDATE filtrado a encontra e COUNT(*) não a conta.
+func softDB(queries *[]string) *fakeDB {
+	return &fakeDB{
+		exec: func(query string, _ []driver.NamedValue) (driver.Result, error) {
+			*queries = append(*queries, query)
+			return driver.RowsAffected(0), nil
+		},
+		query: func(query string, _ []driver.NamedValue) (driver.Rows, error) {
+			*queries = append(*queries, query)
+			return &fakeRows{columns: []string{"COUNT(*)"}, values: [][]driver.Value{{int64(0)}}}, nil
+		},
+	}
+}
+
+func TestUpdateSkipsSoftDeletedRows(t *testing.T) {
+	for _, version := range []int64{0, 3} {
+		var queries []string
+		c := NewCrud(openFake(t, softDB(&queries)), "")
+
+		err := c.UpdateStructContext(context.Background(), "PRODUCTS", &softProduct{ID: 1, Name: "A", Version: version})
+		if !errors.Is(err, ErrNotFound) {
+			t.Fatalf("versão %d: err = %v, esperado ErrNotFound", version, err)
+		}
+		if !strings.Contains(queries[0], "DELETED_AT IS NULL") {
+			t.Fatalf("versão %d: UPDATE sem filtro de exclusão lógica: %s", version, queries[0])
+		}
+	}
+
+	var queries []string
+	c := NewCrud(openFake(t, softDB(&queries)), "")
+	err := c.UpdateFields(context.Background(), "PRODUCTS", &softProduct{ID: 1, Name: "B"}, "NAME")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("UpdateFields: err = %v, esperado ErrNotFound", err)
+	}
+	if !strings.Contains(queries[0], "DELETED_AT IS NULL") {
+		t.Fatalf("UpdateFields sem filtro de exclusão lógica: %s", queries[0])
+	}
+}
+
+func TestDeleteByKeySoftDeletes(t *testing.T) {
+	var queries []string
+	c := NewCrud(openFake(t, softDB(&queries)), "")
+
+	_ = c.DeleteByKeyContext(context.Background(), "PRODUCTS", softProduct{}, int64(1))
+	if len(queries) == 0 || !strings.HasPrefix(queries[0], "UPDATE PRODUCTS SET DELETED_AT = SYSTIMESTAMP") {
+		t.Fatalf("esperado UPDATE de exclusão lógica, executado %v", queries)
+	}
+}
+
+func TestUpsertDoesNotRestoreSoftDeletedRows(t *testing.T) {
+	var query string
+	db := &fakeDB{
+		exec: func(q string, _ []driver.NamedValue) (driver.Result, error) {
+			query = q
+			return nil, &network.OracleError{ErrCode: oraUpsertDeleted, ErrMsg: "ORA-20002: registro excluído"}
+		},
+	}
+	c := NewCrud(openFake(t, db), "")
+
+	_, err := c.Upsert(context.Background(), "PRODUCTS", &softProduct{ID: 1, Name: "A"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, esperado ErrNotFound", err)
+	}
+	if strings.Contains(query, "DELETED_AT = NULL") {
+		t.Fatalf("upsert restaura a linha excluída:\n%s", query)
+	}
+	if !strings.Contains(query, "AND DELETED_AT IS NULL;") {
+		t.Fatalf("UPDATE do upsert sem filtro de exclusão:\n%s", query)
+	}
+	if !strings.Contains(query, "DELETED_AT IS NOT NULL") {
+		t.Fatalf("INSERT sem verificação de linha excluída:\n%s", query)
+	}
+}
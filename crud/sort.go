@@ -0,0 +1,100 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderBy define a ordenação da consulta. Apenas colunas com a opção
+// `sortable` na tag db (ou a pk) são aceitas; a pk é acrescentada como
+// desempate para que a paginação seja determinística.
+func OrderBy(orders ...Order) QueryOption {
+	return func(o *queryOptions) {
+		o.sort = append(o.sort, orders...)
+	}
+}
+
+// ParseSort interpreta o formato "-price,name": itens separados por vírgula,
+// com "-" para ordem decrescente. Os nomes só são validados contra o model
+// em OrderBy, então nada do texto de entrada chega ao SQL.
+func ParseSort(s string) ([]Order, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	items := strings.Split(s, ",")
+	orders := make([]Order, 0, len(items))
+
+	for _, item := range items {
+		o, ok := parseOrder(item)
+		if !ok {
+			return nil, fmt.Errorf("%w: item vazio em %q", ErrInvalidSort, s)
+		}
+		orders = append(orders, o)
+	}
+
+	return orders, nil
+}
+
+// parseOrder interpreta um item de ParseSort; false indica item vazio.
+func parseOrder(item string) (Order, bool) {
+	item = strings.TrimSpace(item)
+
+	var desc bool
+	switch {
+	case strings.HasPrefix(item, "-"):
+		desc = true
+		item = item[1:]
+	case strings.HasPrefix(item, "+"):
+		item = item[1:]
+	}
+
+	return Order{Column: item, Desc: desc}, item != ""
+}
+
+// FormatSort é o inverso de ParseSort, usado para repassar a ordenação a FindSeek.
+func FormatSort(orders []Order) []string {
+	keys := make([]string, len(orders))
+	for i, o := range orders {
+		keys[i] = o.Column
+		if o.Desc {
+			keys[i] = "-" + o.Column
+		}
+	}
+	return keys
+}
+
+func (f *field) sortable() bool {
+	return f.PK || f.has("sortable")
+}
+
+func (m *modelMeta) sortOrders(sort []Order) ([]Order, error) {
+	orders := make([]Order, 0, len(sort)+len(m.Keys))
+	seen := make(map[string]bool, len(sort))
+
+	for _, s := range sort {
+		f := m.fieldByColumn(s.Column)
+		if f == nil || !f.sortable() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSort, s.Column)
+		}
+		if seen[f.Column] {
+			return nil, fmt.Errorf("%w: %q repetida", ErrInvalidSort, s.Column)
+		}
+		seen[f.Column] = true
+
+		orders = append(orders, Order{Column: f.Column, Desc: s.Desc})
+	}
+
+	return m.withPKTiebreak(orders, seen), nil
+}
+
+// withPKTiebreak completa orders com as colunas da pk ainda não usadas, para
+// que a ordenação seja total e a paginação estável.
+func (m *modelMeta) withPKTiebreak(orders []Order, seen map[string]bool) []Order {
+	for _, f := range m.Keys {
+		if !seen[f.Column] {
+			orders = append(orders, Order{Column: f.Column})
+		}
+	}
+	return orders
+}
@@ -0,0 +1,79 @@
+package crud
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []Order
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "  ", want: nil},
+		{in: "name", want: []Order{{Column: "name"}}},
+		{in: "-price, +name", want: []Order{{Column: "price", Desc: true}, {Column: "name"}}},
+		{in: "price,", wantErr: true},
+		{in: ",price", wantErr: true},
+		{in: "-", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSort(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidSort) {
+				t.Errorf("ParseSort(%q) = %v, %v; esperado ErrInvalidSort", tt.in, got, err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSort(%q) = %v, %v; esperado %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestSortOrders(t *testing.T) {
+	meta, err := metaOf(reflect.TypeFor[upsertProduct]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		sort    []Order
+		want    []Order
+		wantErr bool
+	}{
+		{name: "vazio usa a pk", sort: nil, want: []Order{{Column: "ID"}}},
+		{name: "pk é ordenável", sort: []Order{{Column: "id", Desc: true}}, want: []Order{{Column: "ID", Desc: true}}},
+		{name: "coluna sem sortable", sort: []Order{{Column: "NAME"}}, wantErr: true},
+		{name: "coluna desconhecida", sort: []Order{{Column: "SECRET"}}, wantErr: true},
+		{name: "injeção", sort: []Order{{Column: "ID; DROP TABLE X"}}, wantErr: true},
+		{name: "repetida", sort: []Order{{Column: "ID"}, {Column: "ID", Desc: true}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := meta.sortOrders(tt.sort)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSort) {
+					t.Fatalf("sortOrders = %v, %v; esperado ErrInvalidSort", got, err)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("sortOrders = %v, %v; esperado %v", got, err, tt.want)
+			}
+		})
+	}
+
+	meta, err = metaOf(reflect.TypeFor[seekProduct]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := meta.sortOrders([]Order{{Column: "PRICE", Desc: true}})
+	if want := []Order{{Column: "PRICE", Desc: true}, {Column: "ID"}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("desempate pela pk: %v, %v; esperado %v", got, err, want)
+	}
+}
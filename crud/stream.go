@@ -0,0 +1,89 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// errStopIteration interrompe Each quando o consumidor de Stream sai do laço.
+var errStopIteration = errors.New("iteração interrompida")
+
+// Each percorre, uma linha por vez, as linhas de table que satisfazem cond,
+// escaneando cada uma em dest (ponteiro para struct, reaproveitado entre as
+// linhas) e chamando fn. Um erro de fn encerra a leitura e é devolvido.
+//
+// Ao contrário de FindWhere, nada é acumulado: o consumo de memória não
+// depende do número de linhas. O volume buscado a cada ida ao banco segue o
+// PREFETCH_ROWS da conexão (ver database.OpenOracle).
+func (c *Crud) Each(ctx context.Context, table string, dest any, cond Condition, fn func() error, opts ...QueryOption) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest deve ser ponteiro para struct")
+	}
+
+	elem := v.Elem()
+	meta, err := metaOf(elem.Type())
+	if err != nil {
+		return err
+	}
+
+	query, args, err := buildSelect(meta, table, cond, newQueryOptions(opts))
+	if err != nil {
+		return err
+	}
+
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	zero := reflect.Zero(elem.Type())
+	scanTargets := make([]any, 0, len(meta.Fields))
+
+	for rows.Next() {
+		elem.Set(zero)
+		if err := rows.Scan(meta.scanTargets(elem, scanTargets)...); err != nil {
+			return translate(err)
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return translate(rows.Err())
+}
+
+// Stream é a versão em iterador de Each: cada linha é entregue como um novo
+// T. Um erro encerra a sequência após ser entregue com o valor zero de T.
+//
+//	for p, err := range crud.Stream[models.Product](ctx, c, "PRODUCTS", nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Stream[T any](ctx context.Context, c *Crud, table string, cond Condition, opts ...QueryOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var row T
+		err := c.Each(ctx, table, &row, cond, func() error {
+			if !yield(row, nil) {
+				return errStopIteration
+			}
+			return nil
+		}, opts...)
+
+		if err != nil && !errors.Is(err, errStopIteration) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+// DBError é um erro do driver classificado em uma das categorias de errors.go.
+// errors.Is casa tanto com a categoria quanto com o erro original, que segue
+// acessível por errors.As (ex.: *network.OracleError).
+type DBError struct {
+	Kind error
+	Code int // código ORA; zero quando o erro não veio do servidor
+	Err  error
+}
+
+func (e *DBError) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *DBError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// oracleKinds mapeia os códigos ORA conhecidos para sua categoria.
+var oracleKinds = map[int]error{
+	1:     ErrDuplicate,     // unique constraint violated
+	2291:  ErrForeignKey,    // parent key not found
+	2292:  ErrForeignKey,    // child record found
+	1438:  ErrValueTooLarge, // value larger than specified precision
+	12899: ErrValueTooLarge, // value too large for column
+	1013:  ErrTimeout,       // user requested cancel of current operation
+	3113:  ErrConnection,    // end-of-file on communication channel
+	3114:  ErrConnection,    // not connected to ORACLE
+	3135:  ErrConnection,    // connection lost contact
+	12170: ErrConnection,    // TNS: connect timeout occurred
+	12514: ErrConnection,    // TNS: listener does not currently know of service
+	12516: ErrConnection,    // TNS: listener could not find available handler
+	12519: ErrConnection,    // TNS: no appropriate service handler found
+	12520: ErrConnection,    // TNS: listener could not find available handler for requested type of server
+	12528: ErrConnection,    // TNS: listener: all appropriate instances are blocking
+	12537: ErrConnection,    // TNS: connection closed
+	12541: ErrConnection,    // TNS: no listener
+	12543: ErrConnection,    // TNS: destination host unreachable
+
+	oraKeyAboveSeq:   ErrKeyAboveSeq, // levantados por Upsert
+	oraUpsertDeleted: ErrNotFound,
+}
+
+// translate classifica err; erros que não pertencem a nenhuma categoria (de
+// validação, StaleObjectError, ...) são devolvidos sem alteração.
+func translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dbErr *DBError
+	if errors.As(err, &dbErr) {
+		return err
+	}
+
+	kind, code := classify(err)
+	if kind == nil {
+		return err
+	}
+	return &DBError{Kind: kind, Code: code, Err: err}
+}
+
+func classify(err error) (error, int) {
+	var oraErr *network.OracleError
+	if errors.As(err, &oraErr) {
+		if kind, ok := oracleKinds[oraErr.ErrCode]; ok {
+			return kind, oraErr.ErrCode
+		}
+		if oraErr.Bad() {
+			return ErrConnection, oraErr.ErrCode
+		}
+		return nil, 0
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound, 0
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, network.ErrConnReset):
+		return ErrTimeout, 0
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout, 0
+	case errors.As(err, &netErr), errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return ErrConnection, 0
+	}
+	return nil, 0
+}
+
+// notFound é o erro de operações por pk que não afetaram nenhuma linha.
+func notFound() error {
+	return &DBError{Kind: ErrNotFound, Err: sql.ErrNoRows}
+}
+
+// translatingExecutor classifica os erros de ExecContext e QueryContext. Os de
+// QueryRowContext só surgem no Scan e passam por queryRow.
+type translatingExecutor struct {
+	executor
+}
+
+func (e translatingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := e.executor.ExecContext(ctx, query, args...)
+	return result, translate(err)
+}
+
+func (e translatingExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	rows, err := e.executor.QueryContext(ctx, query, args...)
+	return rows, translate(err)
+}
+
+// queryRow executa uma consulta de uma linha e a escaneia em dest, repetindo-a
+// conforme a política de retry do Crud.
+func (c *Crud) queryRow(ctx context.Context, query string, args []any, dest ...any) error {
+	return c.retry.do(ctx, func() error {
+		return translate(c.conn.QueryRowContext(ctx, query, args...).Scan(dest...))
+	})
+}
+
+// affectedOne converte zero linhas afetadas em ErrNotFound.
+func affectedOne(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return translate(err)
+	}
+	if affected == 0 {
+		return notFound()
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+type timeoutErr struct{ timeout bool }
+
+func (e timeoutErr) Error() string   { return "rede" }
+func (e timeoutErr) Timeout() bool   { return e.timeout }
+func (e timeoutErr) Temporary() bool { return false }
+
+var _ net.Error = timeoutErr{}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		code int
+	}{
+		{"unique", network.NewOracleError(1), ErrDuplicate, 1},
+		{"pai inexistente", network.NewOracleError(2291), ErrForeignKey, 2291},
+		{"filho existente", network.NewOracleError(2292), ErrForeignKey, 2292},
+		{"precisão", network.NewOracleError(1438), ErrValueTooLarge, 1438},
+		{"tamanho", network.NewOracleError(12899), ErrValueTooLarge, 12899},
+		{"cancelamento", network.NewOracleError(1013), ErrTimeout, 1013},
+		{"fim de canal", network.NewOracleError(3113), ErrConnection, 3113},
+		{"listener", network.NewOracleError(12541), ErrConnection, 12541},
+		{"pk acima da sequence", &network.OracleError{ErrCode: oraKeyAboveSeq}, ErrKeyAboveSeq, oraKeyAboveSeq},
+		{"upsert de excluído", &network.OracleError{ErrCode: oraUpsertDeleted}, ErrNotFound, oraUpsertDeleted},
+		{"embrulhado", fmt.Errorf("insert: %w", network.NewOracleError(1)), ErrDuplicate, 1},
+		{"sem linhas", sql.ErrNoRows, ErrNotFound, 0},
+		{"prazo", context.DeadlineExceeded, ErrTimeout, 0},
+		{"reset", network.ErrConnReset, ErrTimeout, 0},
+		{"timeout de rede", timeoutErr{timeout: true}, ErrTimeout, 0},
+		{"rede", timeoutErr{}, ErrConnection, 0},
+		{"conexão ruim", driver.ErrBadConn, ErrConnection, 0},
+		{"conexão fechada", sql.ErrConnDone, ErrConnection, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translate(tt.err)
+
+			var dbErr *DBError
+			if !errors.As(err, &dbErr) {
+				t.Fatalf("translate(%v) = %v, esperado *DBError", tt.err, err)
+			}
+			if dbErr.Kind != tt.kind || dbErr.Code != tt.code {
+				t.Fatalf("Kind = %v, Code = %d; esperado %v, %d", dbErr.Kind, dbErr.Code, tt.kind, tt.code)
+			}
+			// errors.Is casa com a categoria e com o erro original
+			if !errors.Is(err, tt.kind) || !errors.Is(err, tt.err) {
+				t.Fatalf("errors.Is não casa %v com %v e %v", err, tt.kind, tt.err)
+			}
+		})
+	}
+}
+
+func TestTranslateKeepsUnclassifiedErrors(t *testing.T) {
+	stale := &StaleObjectError{Table: "PRODUCTS", PK: 1, Version: 2}
+	for _, err := range []error{
+		nil,
+		network.NewOracleError(904), // invalid identifier
+		ErrInvalidColumn,
+		stale,
+	} {
+		if got := translate(err); got != err {
+			t.Fatalf("translate(%v) = %v, esperado o próprio erro", err, got)
+		}
+	}
+
+	// já traduzido não é embrulhado de novo
+	once := translate(network.NewOracleError(1))
+	if translate(once) != once {
+		t.Fatal("erro traduzido duas vezes")
+	}
+}
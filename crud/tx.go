@@ -0,0 +1,73 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// WithTx retorna um Crud que executa todas as operações dentro de tx.
+func (c *Crud) WithTx(tx *sql.Tx) *Crud {
+	return &Crud{db: c.db, conn: translatingExecutor{tx}, tx: tx, schema: c.schema}
+}
+
+func (c *Crud) InTx() bool {
+	return c.tx != nil
+}
+
+// RunInTx executa fn em uma transação, com commit se fn retornar nil e
+// rollback em caso de erro ou panic. Quando chamado a partir de um Crud que
+// já está em transação, usa um SAVEPOINT e desfaz apenas o trecho aninhado.
+func (c *Crud) RunInTx(ctx context.Context, opts *sql.TxOptions, fn func(tx *Crud) error) (err error) {
+	if c.tx != nil {
+		return c.runInSavepoint(ctx, fn)
+	}
+
+	tx, err := c.db.BeginTx(ctx, opts)
+	if err != nil {
+		return translate(err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(c.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	return translate(tx.Commit())
+}
+
+func (c *Crud) runInSavepoint(ctx context.Context, fn func(tx *Crud) error) error {
+	nested := &Crud{db: c.db, conn: translatingExecutor{c.tx}, tx: c.tx, depth: c.depth + 1, schema: c.schema}
+	savepoint := fmt.Sprintf("SP_%d", nested.depth)
+
+	if _, err := c.tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return translate(err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = c.tx.ExecContext(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(p)
+		}
+	}()
+
+	if err := fn(nested); err != nil {
+		if _, rbErr := c.tx.ExecContext(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	// Oracle não possui RELEASE SAVEPOINT; o savepoint expira no commit.
+	return nil
+}
@@ -0,0 +1,161 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UpdateFields atualiza apenas as colunas informadas, com os valores de model,
+// na linha identificada pela pk. Sem colunas, nada é executado.
+func (c *Crud) UpdateFields(ctx context.Context, table string, model any, columns ...string) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]any, len(columns))
+	for _, column := range columns {
+		f, err := meta.updatableField(column)
+		if err != nil {
+			return err
+		}
+		values[f.Column] = f.bindValue(v)
+	}
+
+	return c.updateValues(ctx, table, v, meta, values)
+}
+
+// UpdateMap atualiza as colunas de values (chave = nome da coluna) na linha
+// identificada pela pk de model.
+func (c *Crud) UpdateMap(ctx context.Context, table string, model any, values map[string]any) error {
+	table, err := c.table(table)
+	if err != nil {
+		return err
+	}
+
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return err
+	}
+
+	normalized := make(map[string]any, len(values))
+	for column, value := range values {
+		f, err := meta.updatableField(column)
+		if err != nil {
+			return err
+		}
+		normalized[f.Column] = value
+	}
+
+	return c.updateValues(ctx, table, v, meta, normalized)
+}
+
+// ChangedColumns lista as colunas (exceto pk e colunas gerenciadas) cujo valor difere entre
+// before e after, que devem ser do mesmo tipo de model.
+func ChangedColumns(before, after any) ([]string, error) {
+	bv, meta, err := modelValue(before)
+	if err != nil {
+		return nil, err
+	}
+	av, _, err := modelValue(after)
+	if err != nil {
+		return nil, err
+	}
+	if bv.Type() != av.Type() {
+		return nil, fmt.Errorf("tipos diferentes: %s e %s", bv.Type(), av.Type())
+	}
+
+	var changed []string
+	for _, f := range meta.Fields {
+		if f.PK || meta.managed(f) {
+			continue
+		}
+		if !reflect.DeepEqual(meta.value(bv, f), meta.value(av, f)) {
+			changed = append(changed, f.Column)
+		}
+	}
+
+	return changed, nil
+}
+
+func (m *modelMeta) updatableField(column string) (*field, error) {
+	f := m.fieldByColumn(column)
+	if f == nil || f.PK || m.managed(f) {
+		return nil, fmt.Errorf("%w: %q não pode ser atualizada em %s", ErrInvalidColumn, column, m.Type)
+	}
+	return f, nil
+}
+
+func (c *Crud) updateValues(ctx context.Context, table string, v reflect.Value, meta *modelMeta, values map[string]any) error {
+	if err := meta.requirePK(); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	// ordem estável para reaproveitar o cursor no servidor
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	sets := make([]string, 0, len(columns)+1)
+	args := make([]any, 0, len(columns)+2)
+	for _, column := range columns {
+		args = append(args, values[column])
+		sets = append(sets, fmt.Sprintf("%s = :%d", column, len(args)))
+	}
+
+	argIndex := len(args)
+	next := func() string {
+		argIndex++
+		return fmt.Sprintf(":%d", argIndex)
+	}
+
+	touch, binds := meta.touchSets(next)
+	sets = append(sets, touch...)
+	args = append(args, bindArgs(ctx, meta, v, binds)...)
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s%s",
+		table,
+		strings.Join(sets, ", "),
+		meta.pkWhere(next),
+		meta.notDeleted(),
+	)
+	args = append(args, meta.pkValues(v)...)
+
+	if meta.Version != nil {
+		args = append(args, meta.value(v, meta.Version))
+		query += fmt.Sprintf(" AND %s = :%d", meta.Version.Column, len(args))
+	}
+
+	out := meta.sql(table).updateOut
+	argIndex = len(args)
+	query += returning(out, next)
+	args = append(args, outArgs(v, out)...)
+
+	result, err := c.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if meta.Version == nil {
+		return affectedOne(result)
+	}
+	if err := c.checkVersioned(ctx, table, meta, v, result); err != nil {
+		return err
+	}
+
+	meta.bumpVersion(v)
+	return nil
+}
@@ -0,0 +1,39 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// orderTag só tem colunas de pk, como uma tabela de associação.
+type orderTag struct {
+	OrderID int64  `db:"ORDER_ID,pk"`
+	Tag     string `db:"TAG,pk"`
+}
+
+func TestUpdateWithoutColumnsExecutesNothing(t *testing.T) {
+	var queries []string
+	db := &fakeDB{
+		exec: func(q string, _ []driver.NamedValue) (driver.Result, error) {
+			queries = append(queries, q)
+			return driver.RowsAffected(1), nil
+		},
+	}
+	c := NewCrud(openFake(t, db), "")
+	ctx := context.Background()
+
+	err := c.UpdateStructContext(ctx, "ORDER_TAGS", &orderTag{OrderID: 1, Tag: "urgente"})
+	if !errors.Is(err, ErrInvalidColumn) {
+		t.Fatalf("UpdateStruct: err = %v, esperado ErrInvalidColumn", err)
+	}
+
+	if err := c.UpdateFields(ctx, "ORDER_TAGS", &orderTag{OrderID: 1, Tag: "urgente"}); err != nil {
+		t.Fatalf("UpdateFields sem colunas: %v", err)
+	}
+
+	if len(queries) > 0 {
+		t.Fatalf("comandos executados: %q", queries)
+	}
+}
@@ -0,0 +1,216 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Códigos do RAISE_APPLICATION_ERROR do Upsert: pk que a sequence ainda não
+// emitiu e chave de uma linha excluída logicamente.
+const (
+	oraKeyAboveSeq   = 20001
+	oraUpsertDeleted = 20002
+)
+
+// Upsert insere ou atualiza model casando pelas conflictColumns (a pk quando
+// nenhuma é informada). Linhas novas usam seq.NEXTVAL na pk, exceto quando a
+// própria pk é a chave de conflito. Quando model é ponteiro, a pk da linha
+// resultante é gravada de volta nele. O retorno indica se a linha foi
+// inserida (true) ou atualizada (false).
+//
+// O comando roda em um bloco PL/SQL que tenta o UPDATE e, sem linha afetada,
+// o INSERT; se outra sessão inserir a mesma chave nesse intervalo, o
+// DUP_VAL_ON_INDEX leva de volta ao UPDATE. Em PL/SQL os binds posicionais são
+// associados na ordem em que cada nome aparece no texto, por isso os valores
+// do model são copiados para variáveis no DECLARE, na ordem de numeração.
+//
+// Quando uma pk com seq faz parte da chave e a linha não existe, o valor do
+// model precisa já ter sido emitido pela sequence; um valor maior colidiria
+// com um NEXTVAL futuro e falha com ErrKeyAboveSeq. Linhas excluídas
+// logicamente não são atualizadas nem restauradas: a chave de uma delas falha
+// com ErrNotFound.
+func (c *Crud) Upsert(ctx context.Context, table string, model any, conflictColumns ...string) (bool, error) {
+	table, err := c.table(table)
+	if err != nil {
+		return false, err
+	}
+
+	v, meta, err := modelValue(model)
+	if err != nil {
+		return false, err
+	}
+
+	if len(conflictColumns) == 0 {
+		if err := meta.requirePK(); err != nil {
+			return false, err
+		}
+		for _, f := range meta.Keys {
+			conflictColumns = append(conflictColumns, f.Column)
+		}
+	}
+
+	keys := make(map[*field]bool, len(conflictColumns))
+	for _, column := range conflictColumns {
+		f := meta.fieldByColumn(column)
+		if f == nil || meta.managed(f) {
+			return false, fmt.Errorf("%w: %q não mapeada em %s", ErrInvalidColumn, column, meta.Type)
+		}
+		keys[f] = true
+	}
+
+	var (
+		decls, sets          []string
+		insertCols, insertVs []string
+		keyWhere, checks     []string
+		args                 []any
+		principal            string
+	)
+
+	// declare copia o próximo bind para uma variável do tipo de f
+	declare := func(f *field, value any) string {
+		args = append(args, value)
+		name := fmt.Sprintf("b%d", len(args))
+		decls = append(decls, fmt.Sprintf("  %s %s.%s%%TYPE := :%d;\n", name, table, f.Column, len(args)))
+		return name
+	}
+
+	for _, f := range meta.Fields {
+		if f == meta.Version {
+			insertCols = append(insertCols, f.Column)
+			insertVs = append(insertVs, "1")
+			sets = append(sets, fmt.Sprintf("%s = %s + 1", f.Column, f.Column))
+			continue
+		}
+
+		if f == meta.SoftDelete {
+			continue
+		}
+
+		if f.timestampAudit() {
+			insertCols = append(insertCols, f.Column)
+			insertVs = append(insertVs, "SYSTIMESTAMP")
+			if f.touchedOnUpdate() {
+				sets = append(sets, fmt.Sprintf("%s = SYSTIMESTAMP", f.Column))
+			}
+			continue
+		}
+
+		// o usuário do contexto entra uma única vez e é reaproveitado
+		if f.principalAudit() {
+			if principal == "" {
+				principal = principalExpr(declare(f, PrincipalFrom(ctx)))
+			}
+			insertCols = append(insertCols, f.Column)
+			insertVs = append(insertVs, principal)
+			if f.touchedOnUpdate() {
+				sets = append(sets, fmt.Sprintf("%s = %s", f.Column, principal))
+			}
+			continue
+		}
+
+		if f.generated() {
+			continue
+		}
+
+		// pk fora da chave de conflito nunca vem do model
+		if f.PK && !keys[f] {
+			if f.Seq != "" {
+				insertCols = append(insertCols, f.Column)
+				insertVs = append(insertVs, qualifySequence(table, f.Seq)+".NEXTVAL")
+			}
+			continue
+		}
+
+		name := declare(f, f.bindValue(v))
+		insertCols = append(insertCols, f.Column)
+		insertVs = append(insertVs, name)
+
+		if keys[f] {
+			keyWhere = append(keyWhere, fmt.Sprintf("%s = %s", f.Column, name))
+			if f.PK && f.Seq != "" {
+				checks = append(checks, fmt.Sprintf(
+					"      IF %s > %s.NEXTVAL THEN\n        RAISE_APPLICATION_ERROR(-%d, '%s acima da sequence %s');\n      END IF;\n",
+					name, qualifySequence(table, f.Seq), oraKeyAboveSeq, f.Column, f.Seq,
+				))
+			}
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = %s", f.Column, name))
+	}
+
+	// sem colunas a atualizar, o UPDATE só confirma a existência da linha
+	if len(sets) == 0 {
+		f := meta.fieldByColumn(conflictColumns[0])
+		sets = append(sets, fmt.Sprintf("%s = %s", f.Column, f.Column))
+	}
+
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE %s%s;", table, strings.Join(sets, ", "), strings.Join(keyWhere, " AND "), meta.notDeleted())
+
+	// a chave de uma linha excluída logicamente não é reaproveitada
+	if meta.SoftDelete != nil {
+		checks = append([]string{fmt.Sprintf(
+			"      SELECT COUNT(*) INTO v_deleted FROM %s WHERE %s AND %s IS NOT NULL;\n"+
+				"      IF v_deleted > 0 THEN\n        RAISE_APPLICATION_ERROR(-%d, 'registro excluído');\n      END IF;\n",
+			table, strings.Join(keyWhere, " AND "), meta.SoftDelete.Column, oraUpsertDeleted,
+		)}, checks...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("DECLARE\n")
+	for _, d := range decls {
+		sb.WriteString(d)
+	}
+	sb.WriteString("  v_inserted NUMBER := 0;\n")
+	if meta.SoftDelete != nil {
+		sb.WriteString("  v_deleted NUMBER;\n")
+	}
+	sb.WriteString("BEGIN\n")
+	fmt.Fprintf(&sb, "  %s\n", update)
+	sb.WriteString("  IF SQL%ROWCOUNT = 0 THEN\n    BEGIN\n")
+	for _, check := range checks {
+		sb.WriteString(check)
+	}
+	fmt.Fprintf(&sb, "      INSERT INTO %s (%s) VALUES (%s);\n", table, strings.Join(insertCols, ", "), strings.Join(insertVs, ", "))
+	sb.WriteString("      v_inserted := 1;\n")
+	sb.WriteString("    EXCEPTION\n      WHEN DUP_VAL_ON_INDEX THEN\n")
+	fmt.Fprintf(&sb, "        %s\n", update)
+	sb.WriteString("        IF SQL%ROWCOUNT = 0 THEN\n          RAISE;\n        END IF;\n")
+	sb.WriteString("    END;\n  END IF;\n")
+
+	var inserted int64
+	args = append(args, sql.Out{Dest: &inserted})
+	fmt.Fprintf(&sb, "  :%d := v_inserted;\n", len(args))
+
+	// devolve ao model a pk gerada, a versão resultante e as colunas
+	// preenchidas pelo banco
+	if v.CanAddr() {
+		var columns, into []string
+		returned := append(append([]*field{}, meta.Keys...), meta.Version)
+		for _, f := range append(returned, meta.returnedFields(false)...) {
+			if f == nil || keys[f] {
+				continue
+			}
+			args = append(args, outParam(v.FieldByIndex(f.Index).Addr().Interface()))
+			columns = append(columns, f.Column)
+			into = append(into, fmt.Sprintf(":%d", len(args)))
+		}
+
+		if len(columns) > 0 {
+			fmt.Fprintf(&sb, "  SELECT %s INTO %s FROM %s WHERE %s;\n",
+				strings.Join(columns, ", "),
+				strings.Join(into, ", "),
+				table,
+				strings.Join(keyWhere, " AND "),
+			)
+		}
+	}
+	sb.WriteString("END;")
+
+	if _, err := c.conn.ExecContext(ctx, sb.String(), args...); err != nil {
+		return false, err
+	}
+
+	return inserted == 1, nil
+}
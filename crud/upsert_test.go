@@ -0,0 +1,93 @@
+package crud
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+)
+
+type upsertProduct struct {
+	ID      int64   `db:"ID,pk,seq=PRODUCTS_SEQ"`
+	Name    string  `db:"NAME"`
+	Price   float64 `db:"PRICE"`
+	Version int64   `db:"VERSION,version"`
+}
+
+func TestUpsertBindsAppearInNumericOrder(t *testing.T) {
+	var query string
+	var args []driver.NamedValue
+	db := &fakeDB{
+		exec: func(q string, a []driver.NamedValue) (driver.Result, error) {
+			query, args = q, a
+			return driver.RowsAffected(1), nil
+		},
+	}
+	c := NewCrud(openFake(t, db), "")
+
+	m := upsertProduct{Name: "Caneta", Price: 2.5}
+	if _, err := c.Upsert(context.Background(), "PRODUCTS", &m, "PRICE"); err != nil {
+		t.Fatal(err)
+	}
+
+	// no PL/SQL o n-ésimo argumento vai para o n-ésimo nome distinto no texto
+	seen := map[string]bool{}
+	var order []int
+	for _, match := range regexp.MustCompile(`:(\d+)`).FindAllStringSubmatch(query, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			n, _ := strconv.Atoi(match[1])
+			order = append(order, n)
+		}
+	}
+	for i, n := range order {
+		if n != i+1 {
+			t.Fatalf("binds na ordem %v:\n%s", order, query)
+		}
+	}
+	if len(order) != len(args) {
+		t.Fatalf("%d binds no texto e %d argumentos", len(order), len(args))
+	}
+
+	// o valor de cada coluna precisa chegar à variável da própria coluna
+	values := map[string]any{}
+	for _, match := range regexp.MustCompile(`b\d+ PRODUCTS\.(\w+)%TYPE := :(\d+)`).FindAllStringSubmatch(query, -1) {
+		n, _ := strconv.Atoi(match[2])
+		values[match[1]] = args[n-1].Value
+	}
+	if values["NAME"] != "Caneta" || values["PRICE"] != 2.5 {
+		t.Fatalf("valores por coluna = %v", values)
+	}
+
+	if !strings.Contains(query, "WHEN DUP_VAL_ON_INDEX") {
+		t.Fatalf("INSERT concorrente não tratado:\n%s", query)
+	}
+}
+
+func TestUpsertRejectsKeyAboveSequence(t *testing.T) {
+	var query string
+	db := &fakeDB{
+		exec: func(q string, a []driver.NamedValue) (driver.Result, error) {
+			query = q
+			return nil, &network.OracleError{ErrCode: oraKeyAboveSeq, ErrMsg: "ORA-20001: ID acima da sequence"}
+		},
+	}
+	c := NewCrud(openFake(t, db), "")
+
+	_, err := c.Upsert(context.Background(), "PRODUCTS", &upsertProduct{ID: 999, Name: "Caneta"})
+	if !errors.Is(err, ErrKeyAboveSeq) {
+		t.Fatalf("err = %v, esperado ErrKeyAboveSeq", err)
+	}
+
+	// a verificação precisa vir antes do INSERT
+	check := strings.Index(query, "PRODUCTS_SEQ.NEXTVAL THEN")
+	insert := strings.Index(query, "INSERT INTO")
+	if check < 0 || check > insert {
+		t.Fatalf("pk não comparada com a sequence antes do INSERT:\n%s", query)
+	}
+}
@@ -0,0 +1,68 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// StaleObjectError indica que a linha foi alterada (ou removida e recriada)
+// por outra transação depois de lida: a versão informada não é mais a atual.
+type StaleObjectError struct {
+	Table   string
+	PK      any
+	Version any
+}
+
+func (e *StaleObjectError) Error() string {
+	return fmt.Sprintf("%s %v: versão %v desatualizada", e.Table, e.PK, e.Version)
+}
+
+func (e *StaleObjectError) Is(target error) bool {
+	return target == ErrStaleObject
+}
+
+// checkVersioned converte zero linhas afetadas em um model versionado em
+// *StaleObjectError, ou em ErrNotFound quando a linha não existe mais.
+func (c *Crud) checkVersioned(ctx context.Context, table string, meta *modelMeta, v reflect.Value, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return translate(err)
+	}
+
+	// linhas já excluídas logicamente contam como inexistentes
+	var exists int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", table, meta.pkWhere(positional()))
+	if meta.SoftDelete != nil {
+		query += fmt.Sprintf(" AND %s IS NULL", meta.SoftDelete.Column)
+	}
+	if err := c.queryRow(ctx, query, meta.pkValues(v), &exists); err != nil {
+		return err
+	}
+	if exists == 0 {
+		return notFound()
+	}
+
+	return &StaleObjectError{Table: table, PK: meta.pkValue(v), Version: meta.value(v, meta.Version)}
+}
+
+// bumpVersion reflete no model o incremento feito pelo banco.
+func (m *modelMeta) bumpVersion(v reflect.Value) {
+	if m.Version == nil || !v.CanAddr() {
+		return
+	}
+	if fv := v.FieldByIndex(m.Version.Index); fv.CanInt() {
+		fv.SetInt(fv.Int() + 1)
+	}
+}
+
+// initVersion marca o model recém-inserido com a versão inicial.
+func (m *modelMeta) initVersion(v reflect.Value) {
+	if m.Version == nil || !v.CanAddr() {
+		return
+	}
+	if fv := v.FieldByIndex(m.Version.Index); fv.CanInt() {
+		fv.SetInt(1)
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-api/crud"
 
 	_ "github.com/sijms/go-ora/v2"
 )
@@ -35,6 +40,16 @@ func OpenOracle() *sql.DB {
 
 	dsn := fmt.Sprintf("oracle://%s:%s@%s:%s/%s", user, pass, host, port, service)
 
+	// linhas buscadas por ida ao banco em SELECTs; o go-ora só permite
+	// configurar por conexão. Sem valor, calcula pelo tamanho da linha.
+	if prefetch := os.Getenv("ORACLE_PREFETCH_ROWS"); prefetch != "" {
+		if n, err := strconv.Atoi(prefetch); err != nil || n <= 0 {
+			log.Println("ORACLE_PREFETCH_ROWS inválido, usando o padrão do driver:", prefetch)
+		} else {
+			dsn += "?PREFETCH_ROWS=" + strconv.Itoa(n)
+		}
+	}
+
 	db, err := sql.Open("oracle", dsn)
 	if err != nil {
 		log.Fatal("Erro ao abrir conexão:", err)
@@ -47,3 +62,51 @@ func OpenOracle() *sql.DB {
 	log.Println("✅ Conectado ao Oracle com database/sql")
 	return db
 }
+
+// Schema retorna o schema padrão das tabelas (ORACLE_SCHEMA). Vazio usa o
+// schema do usuário conectado.
+func Schema() string {
+	return strings.TrimSpace(os.Getenv("ORACLE_SCHEMA"))
+}
+
+// RetryPolicy monta a política de retry para falhas transitórias do Oracle:
+// ORACLE_RETRY_ATTEMPTS (tentativas, incluindo a primeira; "1" desativa),
+// ORACLE_RETRY_BASE_DELAY e ORACLE_RETRY_MAX_DELAY (formato de
+// time.ParseDuration). As repetições ficam limitadas a 10% das operações
+// bem-sucedidas, com rajadas de até 10.
+func RetryPolicy() crud.RetryPolicy {
+	return crud.RetryPolicy{
+		MaxAttempts: envInt("ORACLE_RETRY_ATTEMPTS", 3),
+		BaseDelay:   envDuration("ORACLE_RETRY_BASE_DELAY", 100*time.Millisecond),
+		MaxDelay:    envDuration("ORACLE_RETRY_MAX_DELAY", 2*time.Second),
+		Budget:      crud.NewRetryBudget(0.1, 10),
+	}
+}
+
+func envInt(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Println(name+" inválido, usando o padrão:", value)
+		return fallback
+	}
+	return n
+}
+
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Println(name+" inválido, usando o padrão:", value)
+		return fallback
+	}
+	return d
+}
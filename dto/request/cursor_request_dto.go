@@ -0,0 +1,6 @@
+package request
+
+type CursorRequestDTO struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1"`
+}
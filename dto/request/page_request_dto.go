@@ -0,0 +1,6 @@
+package request
+
+type PageRequestDTO struct {
+	Page int `form:"page" binding:"omitempty,min=1"`
+	Size int `form:"size" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type ProductFilterDTO struct {
+	Name     string   `form:"name"`
+	MinPrice *float64 `form:"min_price"`
+	MaxPrice *float64 `form:"max_price"`
+	Sort     string   `form:"sort"`
+
+	IncludeDeleted bool `form:"include_deleted"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// ProductPatchDTO documenta o corpo de PATCH (JSON Merge Patch): campos
+// ausentes não são alterados.
+type ProductPatchDTO struct {
+	Name  *string  `json:"name,omitempty" example:"Teclado Mecânico"`
+	Price *float64 `json:"price,omitempty" example:"449.90"`
+	// null remove a descrição
+	Description *string `json:"description,omitempty" example:"Switches marrons, ABNT2"`
+}
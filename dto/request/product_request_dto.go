@@ -3,4 +3,6 @@ package request
 type ProductRequestDTO struct {
 	Name  string  `json:"name" binding:"required"`
 	Price float64 `json:"price" binding:"required"`
+
+	Description *string `json:"description,omitempty" binding:"omitempty,max=4000"`
 }
@@ -0,0 +1,11 @@
+package response
+
+type BulkErrorDTO struct {
+	Index int    `json:"index" example:"3"`
+	Info  string `json:"info" example:"preço inválido"`
+}
+
+type BulkResponseDTO[T any] struct {
+	Created []T            `json:"created"`
+	Errors  []BulkErrorDTO `json:"errors"`
+}
@@ -0,0 +1,8 @@
+package response
+
+type CursorPageResponseDTO[T any] struct {
+	Items []T    `json:"items"`
+	Limit int    `json:"limit" example:"20"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+}
@@ -0,0 +1,8 @@
+package response
+
+type PageResponseDTO[T any] struct {
+	Items []T   `json:"items"`
+	Page  int   `json:"page" example:"1"`
+	Size  int   `json:"size" example:"20"`
+	Total int64 `json:"total" example:"42"`
+}
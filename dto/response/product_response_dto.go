@@ -1,7 +1,15 @@
 package response
 
+import "time"
+
 type ProductResponseDTO struct {
-	ID    int64   `json:"id" example:"1"`
-	Name  string  `json:"name" example:"Teclado Mecânico"`
-	Price float64 `json:"price" example:"499.90"`
+	ID          int64      `json:"id" example:"1"`
+	Name        string     `json:"name" example:"Teclado Mecânico"`
+	Price       float64    `json:"price" example:"499.90"`
+	Description *string    `json:"description" example:"Switches marrons, ABNT2"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	CreatedBy   *string    `json:"created_by,omitempty" example:"maria"`
+	UpdatedBy   *string    `json:"updated_by,omitempty" example:"joao"`
 }
@@ -0,0 +1,8 @@
+package response
+
+type ProductStatsResponseDTO struct {
+	Count    int64    `json:"count" example:"42"`
+	MinPrice *float64 `json:"min_price" example:"19.90"`
+	MaxPrice *float64 `json:"max_price" example:"4999.00"`
+	AvgPrice *float64 `json:"avg_price" example:"612.35"`
+}
@@ -1,51 +1,253 @@
 package facade
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"product-api/crud"
 	"product-api/models"
 	"product-api/repository"
+	"sort"
 )
 
 type ProductFacade struct {
-	repo *repository.ProductRepository
+	repo    *repository.ProductRepository
+	cursors *crud.CursorCodec
 }
 
-func NewProductFacade(repo *repository.ProductRepository) *ProductFacade {
-	return &ProductFacade{repo: repo}
+func NewProductFacade(repo *repository.ProductRepository, cursors *crud.CursorCodec) *ProductFacade {
+	return &ProductFacade{repo: repo, cursors: cursors}
 }
 
-func (f *ProductFacade) Create(p models.Product) (models.Product, error) {
+func (f *ProductFacade) Create(ctx context.Context, p models.Product) (models.Product, error) {
+	if err := validateProduct(p); err != nil {
+		return p, err
+	}
+
+	return f.repo.Create(ctx, p)
+}
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	MaxBulkSize     = 5000
+)
+
+// CreateMany valida cada item individualmente e insere os válidos em lote.
+// Itens rejeitados, pela validação ou pelo banco, voltam em failures com o
+// índice do lote original.
+func (f *ProductFacade) CreateMany(ctx context.Context, products []models.Product) ([]models.Product, []models.BulkFailure, error) {
+	if len(products) > MaxBulkSize {
+		return nil, nil, fmt.Errorf("lote excede o máximo de %d produtos", MaxBulkSize)
+	}
+
+	var failures []models.BulkFailure
+	valid := make([]models.Product, 0, len(products))
+	positions := make([]int, 0, len(products))
+
+	for i, p := range products {
+		if err := validateProduct(p); err != nil {
+			failures = append(failures, models.BulkFailure{Index: i, Reason: err.Error()})
+			continue
+		}
+		valid = append(valid, p)
+		positions = append(positions, i)
+	}
+
+	if len(valid) == 0 {
+		return nil, failures, nil
+	}
+
+	created, err := f.repo.CreateMany(ctx, valid)
+
+	var batchErr *crud.BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return nil, nil, err
+	}
+
+	if batchErr != nil {
+		rejected := make(map[int]bool, len(batchErr.Rows))
+		for _, row := range batchErr.Rows {
+			rejected[row.Index] = true
+			failures = append(failures, models.BulkFailure{Index: positions[row.Index], Reason: row.Err.Error()})
+		}
+
+		kept := created[:0]
+		for i, p := range created {
+			if !rejected[i] {
+				kept = append(kept, p)
+			}
+		}
+		created = kept
+
+		sort.Slice(failures, func(i, j int) bool { return failures[i].Index < failures[j].Index })
+	}
+
+	return created, failures, nil
+}
+
+func validateProduct(p models.Product) error {
 	if p.Name == "" {
-		return p, errors.New("nome é obrigatório")
+		return errors.New("nome é obrigatório")
 	}
 	if p.Price <= 0 {
-		return p, errors.New("preço inválido")
+		return errors.New("preço inválido")
+	}
+	return nil
+}
+
+// List devolve a página efetivamente usada, já com os limites do servidor aplicados.
+func (f *ProductFacade) List(ctx context.Context, filter models.ProductFilter, page models.Page) ([]models.Product, models.Page, int64, error) {
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, page, 0, errors.New("faixa de preço inválida")
+	}
+
+	if page.Number < 1 {
+		page.Number = 1
+	}
+	if page.Size < 1 {
+		page.Size = DefaultPageSize
+	}
+	if page.Size > MaxPageSize {
+		page.Size = MaxPageSize
 	}
 
-	return f.repo.Create(p)
+	products, total, err := f.repo.List(ctx, filter, page)
+	return products, page, total, err
 }
 
-func (f *ProductFacade) List() ([]models.Product, error) {
-	return f.repo.List()
+func (f *ProductFacade) Stats(ctx context.Context, filter models.ProductFilter) (models.ProductStats, error) {
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return models.ProductStats{}, errors.New("faixa de preço inválida")
+	}
+	return f.repo.Stats(ctx, filter)
 }
 
-func (f *ProductFacade) FindByID(id int64) (models.Product, error) {
-	return f.repo.FindByID(id)
+// ListByCursor pagina por keyset. token vazio começa do início; os tokens
+// devolvidos são opacos e assinados.
+func (f *ProductFacade) ListByCursor(ctx context.Context, filter models.ProductFilter, token string, limit int) ([]models.Product, models.CursorPage, error) {
+	page := models.CursorPage{Limit: limit}
+
+	var cursor crud.Cursor
+	if token != "" {
+		var err error
+		if cursor, err = f.cursors.Decode(token); err != nil {
+			return nil, page, err
+		}
+	}
+
+	if page.Limit < 1 {
+		page.Limit = DefaultPageSize
+	}
+	if page.Limit > MaxPageSize {
+		page.Limit = MaxPageSize
+	}
+
+	products, result, err := f.repo.ListSeek(ctx, filter, cursor, page.Limit)
+	if err != nil {
+		return nil, page, err
+	}
+
+	if page.Next, err = f.encodeCursor(result.Next); err != nil {
+		return nil, page, err
+	}
+	if page.Prev, err = f.encodeCursor(result.Prev); err != nil {
+		return nil, page, err
+	}
+
+	return products, page, nil
 }
 
-func (f *ProductFacade) Update(id int64, p models.Product) (models.Product, error) {
-	if p.Name == "" {
-		return p, errors.New("nome é obrigatório")
+func (f *ProductFacade) encodeCursor(cursor *crud.Cursor) (string, error) {
+	if cursor == nil {
+		return "", nil
 	}
-	if p.Price <= 0 {
-		return p, errors.New("preço inválido")
+	return f.cursors.Encode(*cursor)
+}
+
+func (f *ProductFacade) FindByID(ctx context.Context, id int64, includeDeleted bool) (models.Product, error) {
+	if includeDeleted {
+		return f.repo.FindByID(ctx, id, crud.IncludeDeleted())
+	}
+	return f.repo.FindByID(ctx, id)
+}
+
+// Update segue a semântica de PUT: substitui o produto ou o cria com o id
+// informado quando ele não existe. Com version > 0 (If-Match), só atualiza se
+// o produto ainda estiver nessa versão. Um produto excluído não é recriado e,
+// com ou sem If-Match, resulta em crud.ErrNotFound. O bool retornado indica criação.
+func (f *ProductFacade) Update(ctx context.Context, id int64, version int64, p models.Product) (models.Product, bool, error) {
+	if err := validateProduct(p); err != nil {
+		return p, false, err
 	}
 
 	p.ID = id
-	err := f.repo.Update(p)
-	return p, err
+	if version > 0 {
+		p.Version = version
+		updated, err := f.repo.Update(ctx, p)
+		return updated, false, err
+	}
+
+	return f.repo.Upsert(ctx, p)
 }
 
-func (f *ProductFacade) Delete(id int64) error {
-	return f.repo.Delete(id)
+// Patch lê o produto, aplica apply sobre ele e grava apenas as colunas que
+// mudaram, tudo na mesma transação. A validação vale para o resultado mesclado
+// e version > 0 (If-Match) precisa coincidir com a versão lida.
+func (f *ProductFacade) Patch(ctx context.Context, id int64, version int64, apply func(models.Product) (models.Product, error)) (models.Product, error) {
+	var patched models.Product
+
+	err := f.repo.RunInTx(ctx, func(tx *repository.ProductRepository) error {
+		current, err := tx.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version > 0 && current.Version != version {
+			return &crud.StaleObjectError{Table: current.TableName(), PK: id, Version: version}
+		}
+
+		patched, err = apply(current)
+		if err != nil {
+			return err
+		}
+		patched.ID = id
+		patched.Version = current.Version
+		patched.Audit = current.Audit
+		patched.DeletedAt = current.DeletedAt
+
+		if err := validateProduct(patched); err != nil {
+			return err
+		}
+
+		changed, err := crud.ChangedColumns(current, patched)
+		if err != nil {
+			return err
+		}
+
+		patched, err = tx.UpdateFields(ctx, patched, changed...)
+		return err
+	})
+
+	return patched, err
+}
+
+func (f *ProductFacade) Delete(ctx context.Context, id int64, version int64) error {
+	return f.repo.Delete(ctx, models.Product{ID: id, Version: version})
+}
+
+// Restore desfaz a exclusão lógica e devolve o produto restaurado.
+func (f *ProductFacade) Restore(ctx context.Context, id int64) (models.Product, error) {
+	var restored models.Product
+
+	err := f.repo.RunInTx(ctx, func(tx *repository.ProductRepository) error {
+		if err := tx.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		restored, err = tx.FindByID(ctx, id)
+		return err
+	})
+
+	return restored, err
 }
@@ -0,0 +1,48 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidPatch = errors.New("merge patch inválido")
+
+// MergePatch aplica patch sobre doc conforme o JSON Merge Patch (RFC 7396):
+// membros com null são removidos, objetos são mesclados recursivamente e
+// qualquer outro valor substitui o original.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p any
+
+	if len(doc) > 0 {
+		if err := json.Unmarshal(doc, &target); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, errors.Join(ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any, len(p))
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
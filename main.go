@@ -10,7 +10,12 @@ package main
 // @BasePath /api
 
 import (
+	"context"
+	"os"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 
 	_ "product-api/docs"
 	"product-api/logger"
@@ -19,6 +24,7 @@ import (
 	"product-api/crud"
 	"product-api/database"
 	"product-api/facade"
+	"product-api/middlewares"
 	"product-api/repository"
 	"product-api/routes"
 
@@ -30,17 +36,19 @@ func main() {
 	db := database.OpenOracle()
 	defer db.Close()
 
-	crudSvc := crud.NewCrud(db, "")
+	crudSvc := crud.NewCrud(db, database.Schema()).WithRetry(retryPolicy())
 
 	logger.Init()
 	logger.Logger.Info("Iniciando a API...")
 
 	baseRepo := repository.NewBaseRepository(crudSvc)
 	productRepo := repository.NewProductRepository(baseRepo)
-	productFacade := facade.NewProductFacade(productRepo)
+	productFacade := facade.NewProductFacade(productRepo, cursorCodec())
 	productController := controllers.NewProductController(productFacade)
 
 	r := gin.Default()
+	r.Use(middlewares.Timeout(requestTimeout()))
+	r.Use(middlewares.Principal())
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -49,3 +57,45 @@ func main() {
 
 	r.Run(":8080")
 }
+
+// REQUEST_TIMEOUT aceita o formato de time.ParseDuration (ex.: "15s", "2m").
+// "0" desativa o limite.
+func requestTimeout() time.Duration {
+	value := os.Getenv("REQUEST_TIMEOUT")
+	if value == "" {
+		return 30 * time.Second
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Logger.WithField("REQUEST_TIMEOUT", value).Warn("Timeout inválido, usando 30s")
+		return 30 * time.Second
+	}
+
+	return d
+}
+
+// CURSOR_SECRET assina os tokens de paginação por cursor. Sem ela, uma chave
+// aleatória é gerada e os tokens expiram a cada reinício.
+func cursorCodec() *crud.CursorCodec {
+	secret := os.Getenv("CURSOR_SECRET")
+	if secret == "" {
+		logger.Logger.Warn("CURSOR_SECRET não definida, usando chave aleatória")
+	}
+
+	return crud.NewCursorCodec([]byte(secret))
+}
+
+// retryPolicy registra no log cada nova tentativa após uma falha transitória
+// do Oracle.
+func retryPolicy() crud.RetryPolicy {
+	policy := database.RetryPolicy()
+	policy.OnRetry = func(ctx context.Context, attempt int, delay time.Duration, err error) {
+		logger.Logger.WithFields(log.Fields{
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err,
+		}).Warn("Falha transitória no Oracle, repetindo operação")
+	}
+	return policy
+}
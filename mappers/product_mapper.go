@@ -8,16 +8,40 @@ import (
 
 func ToProductResponse(p models.Product) res.ProductResponseDTO {
 	return res.ProductResponseDTO{
-		ID:    p.ID,
-		Name:  p.Name,
-		Price: p.Price,
+		ID:          p.ID,
+		Name:        p.Name,
+		Price:       p.Price,
+		Description: p.Description,
+		DeletedAt:   p.DeletedAt,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedBy:   p.UpdatedBy,
 	}
 }
 
 func ToProductModel(req req.ProductRequestDTO) models.Product {
 	return models.Product{
-		Name:  req.Name,
-		Price: req.Price,
+		Name:        req.Name,
+		Price:       req.Price,
+		Description: req.Description,
+	}
+}
+
+// ApplyProductRequest copia para p apenas os campos do DTO, preservando os
+// demais (auditoria, versão, exclusão lógica).
+func ApplyProductRequest(p models.Product, req req.ProductRequestDTO) models.Product {
+	p.Name = req.Name
+	p.Price = req.Price
+	p.Description = req.Description
+	return p
+}
+
+func ToProductRequest(p models.Product) req.ProductRequestDTO {
+	return req.ProductRequestDTO{
+		Name:        p.Name,
+		Price:       p.Price,
+		Description: p.Description,
 	}
 }
 
@@ -30,3 +54,70 @@ func ToProductResponseList(products []models.Product) []res.ProductResponseDTO {
 
 	return list
 }
+
+func ToProductFilter(req req.ProductFilterDTO) models.ProductFilter {
+	return models.ProductFilter{
+		Name:     req.Name,
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		Sort:     req.Sort,
+
+		IncludeDeleted: req.IncludeDeleted,
+	}
+}
+
+func ToPage(req req.PageRequestDTO) models.Page {
+	return models.Page{
+		Number: req.Page,
+		Size:   req.Size,
+	}
+}
+
+func ToProductPageResponse(products []models.Product, page models.Page, total int64) res.PageResponseDTO[res.ProductResponseDTO] {
+	return res.PageResponseDTO[res.ProductResponseDTO]{
+		Items: ToProductResponseList(products),
+		Page:  page.Number,
+		Size:  page.Size,
+		Total: total,
+	}
+}
+
+func ToProductCursorPageResponse(products []models.Product, page models.CursorPage) res.CursorPageResponseDTO[res.ProductResponseDTO] {
+	return res.CursorPageResponseDTO[res.ProductResponseDTO]{
+		Items: ToProductResponseList(products),
+		Limit: page.Limit,
+		Next:  page.Next,
+		Prev:  page.Prev,
+	}
+}
+
+func ToProductModelList(reqs []req.ProductRequestDTO) []models.Product {
+	list := make([]models.Product, 0, len(reqs))
+
+	for _, r := range reqs {
+		list = append(list, ToProductModel(r))
+	}
+
+	return list
+}
+
+func ToProductBulkResponse(created []models.Product, failures []models.BulkFailure) res.BulkResponseDTO[res.ProductResponseDTO] {
+	errs := make([]res.BulkErrorDTO, 0, len(failures))
+	for _, f := range failures {
+		errs = append(errs, res.BulkErrorDTO{Index: f.Index, Info: f.Reason})
+	}
+
+	return res.BulkResponseDTO[res.ProductResponseDTO]{
+		Created: ToProductResponseList(created),
+		Errors:  errs,
+	}
+}
+
+func ToProductStatsResponse(stats models.ProductStats) res.ProductStatsResponseDTO {
+	return res.ProductStatsResponseDTO{
+		Count:    stats.Count,
+		MinPrice: stats.MinPrice,
+		MaxPrice: stats.MaxPrice,
+		AvgPrice: stats.AvgPrice,
+	}
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"product-api/crud"
+	"product-api/logger"
+	"product-api/services"
+)
+
+// Principal identifica o usuário pelo header `Authorization: Bearer <token>` e
+// o propaga no contexto da requisição, de onde o crud preenche as colunas de
+// auditoria. Requisições sem token seguem anônimas; token inválido é rejeitado.
+func Principal() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "header Authorization inválido"})
+			return
+		}
+
+		if err := services.ValidateToken(c.Request.Context(), token); errors.Is(err, services.ErrInvalidToken) {
+			logger.Logger.WithFields(log.Fields{"error": err}).Warn("token rejeitado")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token inválido"})
+			return
+		} else if err != nil {
+			logger.Logger.WithFields(log.Fields{"error": err}).Error("Falha ao validar token")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "serviço de autenticação indisponível"})
+			return
+		}
+
+		subject, err := services.TokenSubject(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Request = c.Request.WithContext(crud.WithPrincipal(c.Request.Context(), subject))
+		c.Next()
+	}
+}
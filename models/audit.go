@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Audit reúne as colunas de auditoria preenchidas pelo crud; embutida em um
+// model, seus campos viram colunas dele. São ponteiros porque linhas gravadas
+// antes da auditoria têm essas colunas em NULL.
+type Audit struct {
+	CreatedAt *time.Time `db:"CREATED_AT,autocreate"`
+	UpdatedAt *time.Time `db:"UPDATED_AT,autoupdate"`
+	CreatedBy *string    `db:"CREATED_BY,createdby"`
+	UpdatedBy *string    `db:"UPDATED_BY,updatedby"`
+}
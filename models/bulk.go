@@ -0,0 +1,8 @@
+package models
+
+// BulkFailure identifica um item rejeitado em uma operação em lote pela sua
+// posição no lote original.
+type BulkFailure struct {
+	Index  int
+	Reason string
+}
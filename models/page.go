@@ -0,0 +1,17 @@
+package models
+
+type Page struct {
+	Number int
+	Size   int
+}
+
+func (p Page) Offset() int {
+	return (p.Number - 1) * p.Size
+}
+
+// CursorPage descreve uma página de keyset com os tokens das páginas vizinhas.
+type CursorPage struct {
+	Limit int
+	Next  string
+	Prev  string
+}
@@ -1,9 +1,16 @@
 package models
 
+import "time"
+
 type Product struct {
-	ID    int64   `db:"ID,pk,seq=SEQ_PRODUCTS"`
-	Name  string  `db:"NAME"`
-	Price float64 `db:"PRICE"`
+	ID          int64      `db:"ID,pk,seq=SEQ_PRODUCTS"`
+	Name        string     `db:"NAME,sortable"`
+	Price       float64    `db:"PRICE,sortable"`
+	Description *string    `db:"DESCRIPTION"`
+	Version     int64      `db:"VERSION,version"`
+	DeletedAt   *time.Time `db:"DELETED_AT,softdelete"`
+
+	Audit
 }
 
 func (Product) TableName() string {
@@ -0,0 +1,10 @@
+package models
+
+type ProductFilter struct {
+	Name     string
+	MinPrice *float64
+	MaxPrice *float64
+	Sort     string
+
+	IncludeDeleted bool
+}
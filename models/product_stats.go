@@ -0,0 +1,10 @@
+package models
+
+// ProductStats é o resultado da agregação sobre PRODUCTS; os preços são nil
+// quando nenhum produto participa.
+type ProductStats struct {
+	Count    int64    `db:",count"`
+	MinPrice *float64 `db:"PRICE,min"`
+	MaxPrice *float64 `db:"PRICE,max"`
+	AvgPrice *float64 `db:"PRICE,avg"`
+}
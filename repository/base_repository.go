@@ -1,7 +1,10 @@
-
 package repository
 
-import "product-api/crud"
+import (
+	"context"
+
+	"product-api/crud"
+)
 
 type BaseRepository struct {
 	crud *crud.Crud
@@ -10,3 +13,34 @@ type BaseRepository struct {
 func NewBaseRepository(crud *crud.Crud) *BaseRepository {
 	return &BaseRepository{crud: crud}
 }
+
+// RunInTx executa fn com um repositório ligado a uma única transação Oracle.
+func (r *BaseRepository) RunInTx(ctx context.Context, fn func(tx *BaseRepository) error) error {
+	return r.crud.RunInTx(ctx, nil, func(tx *crud.Crud) error {
+		return fn(NewBaseRepository(tx))
+	})
+}
+
+// InSchema retorna um repositório que opera sobre as tabelas de schema.
+func (r *BaseRepository) InSchema(schema string) *BaseRepository {
+	return NewBaseRepository(r.crud.InSchema(schema))
+}
+
+// FindByKey carrega em dest (ponteiro para o model) a linha identificada por
+// key, uma struct com as colunas da pk em tags db. Atende tabelas de pk
+// composta, em que um único id não basta.
+func (r *BaseRepository) FindByKey(ctx context.Context, table string, dest any, key any, opts ...crud.QueryOption) error {
+	if err := crud.ApplyKey(dest, key); err != nil {
+		return err
+	}
+	return r.crud.FindByIDContext(ctx, table, dest, opts...)
+}
+
+// DeleteByKey remove a linha identificada por key; model (ponteiro) define o
+// tipo e, se versionado, a versão esperada.
+func (r *BaseRepository) DeleteByKey(ctx context.Context, table string, model any, key any) error {
+	if err := crud.ApplyKey(model, key); err != nil {
+		return err
+	}
+	return r.crud.DeleteByPKContext(ctx, table, model)
+}
@@ -1,55 +1,128 @@
 package repository
 
-import "product-api/models"
+import (
+	"context"
+
+	"product-api/crud"
+	"product-api/models"
+)
 
 type ProductRepository struct {
-	*BaseRepository
+	*Repository[models.Product]
 }
 
 func NewProductRepository(base *BaseRepository) *ProductRepository {
-	return &ProductRepository{BaseRepository: base}
+	return &ProductRepository{Repository: NewRepository[models.Product](base)}
+}
+
+func (r *ProductRepository) RunInTx(ctx context.Context, fn func(tx *ProductRepository) error) error {
+	return r.BaseRepository.RunInTx(ctx, func(base *BaseRepository) error {
+		return fn(NewProductRepository(base))
+	})
+}
+
+func (r *ProductRepository) InSchema(schema string) *ProductRepository {
+	return NewProductRepository(r.BaseRepository.InSchema(schema))
 }
 
-func (r *ProductRepository) Create(p models.Product) (models.Product, error) {
-	var id int64
+// CreateMany grava os IDs gerados em products; em caso de *crud.BatchError os
+// produtos que falharam ficam com ID zero.
+func (r *ProductRepository) CreateMany(ctx context.Context, products []models.Product) ([]models.Product, error) {
+	var p models.Product
+	_, err := r.crud.CreateMany(ctx, p.TableName(), products)
+	return products, err
+}
+
+// List filtra e pagina produtos, devolvendo também o total; a listagem
+// genérica por condição continua em r.Repository.List.
+func (r *ProductRepository) List(ctx context.Context, filter models.ProductFilter, page models.Page) ([]models.Product, int64, error) {
+	var products []models.Product
+
+	var p models.Product
+	cond := productConditions(filter)
+
+	sort, err := crud.ParseSort(filter.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scope := productScope(filter)
 
-	err := r.crud.CreateStructReturningID(
-		p.TableName(),
-		p,
-		&id,
-	)
+	total, err := r.crud.Count(ctx, p.TableName(), p, cond, scope...)
 	if err != nil {
-		return p, err
+		return nil, 0, err
 	}
 
-	p.ID = id
-	return p, nil
+	opts := append(scope, crud.OrderBy(sort...), crud.Paginate(page.Offset(), page.Size))
+	err = r.crud.FindWhere(ctx, p.TableName(), &products, cond, opts...)
+
+	return products, total, err
 }
 
-func (r *ProductRepository) List() ([]models.Product, error) {
+// ListSeek pagina por keyset na ordenação pedida (pk por padrão), adequado
+// para varrer a tabela inteira.
+func (r *ProductRepository) ListSeek(ctx context.Context, filter models.ProductFilter, cursor crud.Cursor, limit int) ([]models.Product, crud.SeekResult, error) {
 	var products []models.Product
 
+	sort, err := crud.ParseSort(filter.Sort)
+	if err != nil {
+		return nil, crud.SeekResult{}, err
+	}
+
 	var p models.Product
-	err := r.crud.ListStruct(p.TableName(), &products)
+	result, err := r.crud.FindSeek(ctx, p.TableName(), &products, productConditions(filter), cursor, limit, crud.FormatSort(sort), productScope(filter)...)
 
-	return products, err
+	return products, result, err
 }
 
-func (r *ProductRepository) Update(p models.Product) error {
-	return r.crud.UpdateStruct(p.TableName(), p)
+// Stats agrega os produtos do filtro: quantidade e preço mínimo, máximo e médio.
+func (r *ProductRepository) Stats(ctx context.Context, filter models.ProductFilter) (models.ProductStats, error) {
+	var stats models.ProductStats
+
+	var p models.Product
+	err := r.crud.Aggregate(ctx, p.TableName(), p, &stats, productConditions(filter), productScope(filter)...)
+
+	return stats, err
 }
 
-func (r *ProductRepository) Delete(id int64) error {
-	p := models.Product{
-		ID: id,
+func productConditions(filter models.ProductFilter) crud.Condition {
+	var conds []crud.Condition
+
+	if filter.Name != "" {
+		conds = append(conds, crud.Like("NAME", "%"+crud.EscapeLike(filter.Name)+"%"))
+	}
+	if filter.MinPrice != nil {
+		conds = append(conds, crud.Ge("PRICE", *filter.MinPrice))
 	}
-	return r.crud.DeleteByPK(p.TableName(), &p)
+	if filter.MaxPrice != nil {
+		conds = append(conds, crud.Le("PRICE", *filter.MaxPrice))
+	}
+
+	return crud.And(conds...)
 }
 
-func (r *ProductRepository) FindByID(id int64) (models.Product, error) {
-	var p models.Product
-	p.ID = id
+func productScope(filter models.ProductFilter) []crud.QueryOption {
+	if filter.IncludeDeleted {
+		return []crud.QueryOption{crud.IncludeDeleted()}
+	}
+	return nil
+}
 
-	err := r.crud.FindByID(p.TableName(), &p)
+func (r *ProductRepository) UpdateFields(ctx context.Context, p models.Product, columns ...string) (models.Product, error) {
+	err := r.crud.UpdateFields(ctx, p.TableName(), &p, columns...)
 	return p, err
 }
+
+// Upsert cria ou atualiza p casando pelas colunas informadas (pk por padrão).
+// O bool retornado indica se o produto foi criado.
+func (r *ProductRepository) Upsert(ctx context.Context, p models.Product, conflictColumns ...string) (models.Product, bool, error) {
+	created, err := r.crud.Upsert(ctx, p.TableName(), &p, conflictColumns...)
+	return p, created, err
+}
+
+func (r *ProductRepository) Restore(ctx context.Context, id int64) error {
+	p := models.Product{
+		ID: id,
+	}
+	return r.crud.Restore(ctx, p.TableName(), &p)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"iter"
+
+	"product-api/crud"
+)
+
+// Model é um struct com tags db que informa a própria tabela.
+type Model interface {
+	TableName() string
+}
+
+// Repository oferece o CRUD tipado de T sobre o crud: uma nova entidade só
+// precisa do model. Repositórios específicos o embutem e acrescentam as
+// próprias consultas.
+type Repository[T Model] struct {
+	*BaseRepository
+}
+
+func NewRepository[T Model](base *BaseRepository) *Repository[T] {
+	return &Repository[T]{BaseRepository: base}
+}
+
+func (r *Repository[T]) table() string {
+	var m T
+	return m.TableName()
+}
+
+// Create insere m e o devolve com a pk e as colunas geradas pelo banco.
+func (r *Repository[T]) Create(ctx context.Context, m T) (T, error) {
+	err := r.crud.CreateStructContext(ctx, r.table(), &m)
+	return m, err
+}
+
+// FindByID busca pela pk: key é o valor da pk simples ou uma struct com as
+// colunas da pk composta. ErrNotFound indica que não existe.
+func (r *Repository[T]) FindByID(ctx context.Context, key any, opts ...crud.QueryOption) (T, error) {
+	var m T
+	err := r.FindByKey(ctx, r.table(), &m, key, opts...)
+	return m, err
+}
+
+// List devolve as linhas que satisfazem cond (nil para todas).
+func (r *Repository[T]) List(ctx context.Context, cond crud.Condition, opts ...crud.QueryOption) ([]T, error) {
+	var list []T
+	err := r.crud.FindWhere(ctx, r.table(), &list, cond, opts...)
+	return list, err
+}
+
+// Count conta as linhas que satisfazem cond.
+func (r *Repository[T]) Count(ctx context.Context, cond crud.Condition, opts ...crud.QueryOption) (int64, error) {
+	var m T
+	return r.crud.Count(ctx, r.table(), m, cond, opts...)
+}
+
+// Exists indica se alguma linha satisfaz cond.
+func (r *Repository[T]) Exists(ctx context.Context, cond crud.Condition, opts ...crud.QueryOption) (bool, error) {
+	var m T
+	return r.crud.Exists(ctx, r.table(), m, cond, opts...)
+}
+
+// Stream percorre as linhas que satisfazem cond sem carregá-las todas em
+// memória, para exportações e processamentos em lote.
+func (r *Repository[T]) Stream(ctx context.Context, cond crud.Condition, opts ...crud.QueryOption) iter.Seq2[T, error] {
+	return crud.Stream[T](ctx, r.crud, r.table(), cond, opts...)
+}
+
+// Update grava m pela pk; em models versionados exige a versão lida e devolve
+// m com a nova versão.
+func (r *Repository[T]) Update(ctx context.Context, m T) (T, error) {
+	err := r.crud.UpdateStructContext(ctx, r.table(), &m)
+	return m, err
+}
+
+// Delete remove a linha da pk de m (logicamente com `softdelete`); com versão
+// diferente de zero, só se ela ainda for a atual.
+func (r *Repository[T]) Delete(ctx context.Context, m T) error {
+	return r.crud.DeleteByPKContext(ctx, r.table(), &m)
+}
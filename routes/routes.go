@@ -8,8 +8,12 @@ import (
 
 func Register(r *gin.RouterGroup, product *controllers.ProductController) {
 	r.POST("/products", product.Create)
+	r.POST("/products/bulk", product.CreateMany)
 	r.GET("/products", product.List)
+	r.GET("/products/stats", product.Stats)
 	r.GET("/products/:id", product.FindByID)
 	r.PUT("/products/:id", product.Update)
+	r.PATCH("/products/:id", product.Patch)
 	r.DELETE("/products/:id", product.Delete)
+	r.POST("/products/:id/restore", product.Restore)
 }
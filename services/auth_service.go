@@ -1,12 +1,28 @@
 package services
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 )
 
-func ValidateToken(token string) error {
-	req, err := http.NewRequest(
+var ErrInvalidToken = errors.New("token inválido")
+
+// authClient limita a espera pelo serviço de autenticação mesmo quando o
+// contexto da requisição não tem prazo.
+var authClient = &http.Client{Timeout: 5 * time.Second}
+
+// ValidateToken consulta o serviço de autenticação. ErrInvalidToken indica
+// token recusado (401 ou 403); os demais erros, inclusive outros status, são
+// falhas ao falar com o serviço.
+func ValidateToken(ctx context.Context, token string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		"http://localhost:8081/validate",
 		nil,
@@ -17,16 +33,41 @@ func ValidateToken(token string) error {
 
 	req.Header.Set("Authorization", "Bearer "+token)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := authClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("token inválido")
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrInvalidToken
+	}
+
+	return fmt.Errorf("serviço de autenticação respondeu %d", resp.StatusCode)
+}
+
+// TokenSubject extrai a claim "sub" de um JWT. A assinatura não é verificada
+// aqui: o token deve ter sido aceito antes por ValidateToken.
+func TokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("token malformado")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.New("token malformado")
+	}
+
+	var claims struct {
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", errors.New("token malformado")
 	}
 
-	return nil
+	return claims.Sub, nil
 }